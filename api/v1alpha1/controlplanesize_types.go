@@ -0,0 +1,48 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControlPlaneSizeSpec defines the preset applied to the Tenant Control Planes referring to the size:
+// the values explicitly declared in the Tenant Control Plane specification take precedence.
+type ControlPlaneSizeSpec struct {
+	// Replicas is the number of the Tenant Control Plane Pods.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources defines the amount of memory and CPU to allocate to each component of the Control Plane
+	// (kube-apiserver, controller-manager, scheduler, and kine).
+	Resources *ControlPlaneComponentsResources `json:"resources,omitempty"`
+	// ExtraArgs defines the arguments of the Control Plane components, such as the API Server tuning flags
+	// (e.g. --max-requests-inflight, or --default-watch-cache-size), and the kine ones:
+	// the arguments declared in the Tenant Control Plane specification override the ones with the same flag.
+	ExtraArgs *ControlPlaneExtraArgs `json:"extraArgs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=cps
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="Tenant Control Plane replicas"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// ControlPlaneSize is the Schema for the controlplanesizes API.
+type ControlPlaneSize struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ControlPlaneSizeSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ControlPlaneSizeList contains a list of ControlPlaneSize.
+type ControlPlaneSizeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ControlPlaneSize `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ControlPlaneSize{}, &ControlPlaneSizeList{})
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,3 +38,47 @@ func (in *ContentRef) GetContent(ctx context.Context, client client.Client) ([]b
 
 	return v, nil
 }
+
+// SharedDatabase returns the name of the PostgreSQL database hosting the tenant schemas,
+// which is empty unless the PostgreSQL driver is used along with the Schema isolation.
+func (in *DataStore) SharedDatabase() string {
+	if in.Spec.Driver != KinePostgreSQLDriver || in.Spec.PostgreSQL == nil || in.Spec.PostgreSQL.Isolation != PostgreSQLSchemaIsolation {
+		return ""
+	}
+
+	return in.Spec.PostgreSQL.SharedDatabase
+}
+
+// UserPolicy returns the restrictions applied to the datastore user of the given Tenant Control Plane:
+// the fields set by the Tenant Control Plane take precedence over the DataStore ones.
+func (in *DataStore) UserPolicy(tcp *TenantControlPlane) DataStoreUserPolicy {
+	var policy DataStoreUserPolicy
+
+	if in.Spec.UserPolicy != nil {
+		in.Spec.UserPolicy.DeepCopyInto(&policy)
+	}
+
+	override := tcp.Spec.DataStoreUserPolicy
+	if override == nil {
+		return policy
+	}
+
+	if override.AllowedHosts != nil {
+		policy.AllowedHosts = append([]string(nil), override.AllowedHosts...)
+	}
+
+	if maxConnections := override.MaxConnections; maxConnections != nil {
+		policy.MaxConnections = new(int32)
+		*policy.MaxConnections = *maxConnections
+	}
+
+	if statementTimeout := override.StatementTimeout; statementTimeout != nil {
+		policy.StatementTimeout = &metav1.Duration{Duration: statementTimeout.Duration}
+	}
+
+	if lockTimeout := override.LockTimeout; lockTimeout != nil {
+		policy.LockTimeout = &metav1.Duration{Duration: lockTimeout.Duration}
+	}
+
+	return policy
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDataStoreUserPolicy(t *testing.T) {
+	dsConnections, tcpConnections := int32(100), int32(10)
+
+	ds := &DataStore{}
+	ds.Spec.UserPolicy = &DataStoreUserPolicy{
+		AllowedHosts:     []string{"10.0.0.0/8"},
+		MaxConnections:   &dsConnections,
+		StatementTimeout: &metav1.Duration{Duration: time.Minute},
+	}
+
+	tcp := &TenantControlPlane{}
+
+	if policy := ds.UserPolicy(tcp); !reflect.DeepEqual(policy, *ds.Spec.UserPolicy) {
+		t.Fatalf("expected the DataStore policy, got %v", policy)
+	}
+
+	tcp.Spec.DataStoreUserPolicy = &DataStoreUserPolicy{
+		MaxConnections: &tcpConnections,
+		LockTimeout:    &metav1.Duration{Duration: time.Second},
+	}
+
+	expected := DataStoreUserPolicy{
+		AllowedHosts:     []string{"10.0.0.0/8"},
+		MaxConnections:   &tcpConnections,
+		StatementTimeout: &metav1.Duration{Duration: time.Minute},
+		LockTimeout:      &metav1.Duration{Duration: time.Second},
+	}
+
+	policy := ds.UserPolicy(tcp)
+	if !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("expected %v, got %v", expected, policy)
+	}
+	// The returned policy must not share the DataStore values.
+	*policy.MaxConnections = 1
+	policy.AllowedHosts[0] = "192.168.0.0/16"
+
+	if *tcp.Spec.DataStoreUserPolicy.MaxConnections != tcpConnections || ds.Spec.UserPolicy.AllowedHosts[0] != "10.0.0.0/8" {
+		t.Fatal("the returned policy is sharing the source values")
+	}
+}
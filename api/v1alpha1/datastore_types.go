@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,6 +35,90 @@ type DataStoreSpec struct {
 	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
 	// Defines the TLS/SSL configuration required to connect to the data store in a secure way.
 	TLSConfig TLSConfig `json:"tlsConfig"`
+	// Defines the options specific to the PostgreSQL driver, ignored by the other ones.
+	PostgreSQL *PostgreSQLOptions `json:"postgreSQL,omitempty"`
+	// Defines the restrictions applied to the datastore users of the Tenant Control Planes,
+	// which can be overridden by each Tenant Control Plane.
+	UserPolicy *DataStoreUserPolicy `json:"userPolicy,omitempty"`
+	// Cordoned prevents the DataStore from being used by new Tenant Control Planes, such as when decommissioning it:
+	// it's not assigned anymore by default, and it cannot be referred by new, or migrating, Tenant Control Planes.
+	// The Tenant Control Planes already using the DataStore are not affected.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// Drain migrates the Tenant Control Planes using the DataStore to the target one, one at a time,
+	// requiring the DataStore to be cordoned.
+	Drain *DataStoreDrainSpec `json:"drain,omitempty"`
+	// EtcdMaintenance enables the periodic maintenance of the etcd members, performed one at a time,
+	// and reported in the DataStore status: supported by the etcd driver only.
+	EtcdMaintenance *EtcdMaintenanceSpec `json:"etcdMaintenance,omitempty"`
+}
+
+// DataStoreDrainSpec defines the target of the Tenant Control Planes migration upon the DataStore drain.
+type DataStoreDrainSpec struct {
+	// TargetDataStore is the DataStore the Tenant Control Planes are migrated to:
+	// it must use the same driver, and it cannot be cordoned.
+	// +kubebuilder:validation:MinLength=1
+	TargetDataStore string `json:"targetDataStore"`
+}
+
+// EtcdMaintenanceSpec defines the maintenance of the etcd members performed by Kamaji.
+// The keyspace is compacted by the API Servers of the Tenant Control Planes: Kamaji compacts it only
+// upon a NOSPACE alarm, when disarming the alarms is enabled, since the API Servers cannot write anymore.
+type EtcdMaintenanceSpec struct {
+	// DefragmentationInterval is the minimum interval between two defragmentations of the same member.
+	// +kubebuilder:default="24h"
+	DefragmentationInterval metav1.Duration `json:"defragmentationInterval,omitempty"`
+	// DefragmentationThreshold is the minimum size of the member database not in use, such as the space freed
+	// by the compaction, triggering its defragmentation.
+	// +kubebuilder:default="100Mi"
+	DefragmentationThreshold resource.Quantity `json:"defragmentationThreshold,omitempty"`
+	// DisarmAlarms compacts the keyspace, and defragments the members raising a NOSPACE alarm, regardless of the interval
+	// and the threshold, disarming the alarm afterwards: the alarm is raised again if the database is still exceeding its quota.
+	// The other alarms, such as CORRUPT, require a manual intervention.
+	DisarmAlarms bool `json:"disarmAlarms,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Database;Schema
+
+type PostgreSQLIsolation string
+
+var (
+	PostgreSQLDatabaseIsolation PostgreSQLIsolation = "Database"
+	PostgreSQLSchemaIsolation   PostgreSQLIsolation = "Schema"
+)
+
+// PostgreSQLOptions defines how the Tenant Control Planes data is stored in the PostgreSQL server.
+type PostgreSQLOptions struct {
+	// Isolation defines how the data of each Tenant Control Plane is isolated:
+	// with Database, a database is created for each tenant, with Schema, a schema is created for each tenant
+	// in the shared database, along with a dedicated role whose search_path is set to the tenant schema.
+	// The isolation cannot be changed while the DataStore is used by any Tenant Control Plane.
+	// +kubebuilder:default=Database
+	Isolation PostgreSQLIsolation `json:"isolation,omitempty"`
+	// SharedDatabase is the name of the database hosting the tenant schemas when using the Schema isolation,
+	// it's created if not existing.
+	// +kubebuilder:default=kamaji
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_]*$`
+	SharedDatabase string `json:"sharedDatabase,omitempty"`
+}
+
+// DataStoreUserPolicy defines the restrictions applied to the datastore user of a Tenant Control Plane,
+// preventing a single tenant from exhausting the resources of the shared datastore.
+// The changes are applied to the existing users upon the next reconciliation.
+type DataStoreUserPolicy struct {
+	// AllowedHosts are the client hosts the user can connect from, such as host names, IP addresses, or IPv4 CIDRs:
+	// when empty, the user can connect from any host.
+	// Supported by the MySQL driver only, since the PostgreSQL client authentication is configured in the pg_hba.conf file.
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// MaxConnections is the maximum number of concurrent connections of the user, unlimited when not set.
+	// With the MySQL driver, the limit is applied to each allowed host.
+	// +kubebuilder:validation:Minimum=1
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+	// StatementTimeout aborts the statements of the user running longer than the given duration.
+	// Supported by the PostgreSQL driver only.
+	StatementTimeout *metav1.Duration `json:"statementTimeout,omitempty"`
+	// LockTimeout aborts the statements of the user waiting for a lock longer than the given duration.
+	// Supported by the PostgreSQL driver only.
+	LockTimeout *metav1.Duration `json:"lockTimeout,omitempty"`
 }
 
 // TLSConfig contains the information used to connect to the data store using a secured connection.
@@ -82,12 +167,75 @@ type SecretReference struct {
 type DataStoreStatus struct {
 	// List of the Tenant Control Planes, namespaced named, using this data store.
 	UsedBy []string `json:"usedBy,omitempty"`
+	// Drain reports the progress of the drain operation, if any.
+	Drain *DataStoreDrainStatus `json:"drain,omitempty"`
+	// Etcd reports the status of the etcd members, when the maintenance is enabled.
+	Etcd *EtcdMaintenanceStatus `json:"etcd,omitempty"`
+}
+
+// EtcdMaintenanceStatus reports the status of the etcd members collected upon the maintenance.
+type EtcdMaintenanceStatus struct {
+	// Members reports the status of the member served by each endpoint.
+	Members []EtcdMemberStatus `json:"members,omitempty"`
+	// LastUpdate is the time of the last collection of the members status.
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// EtcdMemberStatus reports the database size, and the maintenance, of an etcd member.
+type EtcdMemberStatus struct {
+	// Endpoint is the DataStore endpoint serving the member.
+	Endpoint string `json:"endpoint"`
+	// MemberID is the hexadecimal ID of the member.
+	MemberID string `json:"memberID,omitempty"`
+	// DBSize is the size in bytes of the member database, including the space not in use.
+	DBSize int64 `json:"dbSize,omitempty"`
+	// DBSizeInUse is the size in bytes of the member database in use.
+	DBSizeInUse int64 `json:"dbSizeInUse,omitempty"`
+	// Fragmentation is the percentage of the member database not in use, reclaimed by the defragmentation.
+	Fragmentation int32 `json:"fragmentation,omitempty"`
+	// Alarms are the alarms raised by the member, such as NOSPACE, or CORRUPT.
+	Alarms []string `json:"alarms,omitempty"`
+	// LastDefragmentation is the time of the last defragmentation of the member performed by Kamaji.
+	LastDefragmentation *metav1.Time `json:"lastDefragmentation,omitempty"`
+	// Message reports why the member status cannot be retrieved, or why its last defragmentation failed.
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Draining;Failed;Drained
+
+type DataStoreDrainPhase string
+
+var (
+	DataStoreDrainPhaseDraining DataStoreDrainPhase = "Draining"
+	DataStoreDrainPhaseFailed   DataStoreDrainPhase = "Failed"
+	DataStoreDrainPhaseDrained  DataStoreDrainPhase = "Drained"
+)
+
+// DataStoreDrainStatus reports the progress of the Tenant Control Planes migration to the target DataStore.
+type DataStoreDrainStatus struct {
+	// TargetDataStore is the DataStore the Tenant Control Planes are migrated to.
+	TargetDataStore string `json:"targetDataStore"`
+	// Phase is Draining while the Tenant Control Planes are migrated, Failed when the migration
+	// of the current one failed, halting the drain, or when the skipped ones only are left,
+	// and Drained once no Tenant Control Plane is using the DataStore.
+	Phase DataStoreDrainPhase `json:"phase"`
+	// Current is the namespaced name of the Tenant Control Plane being migrated.
+	Current string `json:"current,omitempty"`
+	// Migrated lists the namespaced names of the Tenant Control Planes migrated to the target DataStore.
+	Migrated []string `json:"migrated,omitempty"`
+	// Skipped lists the namespaced names of the Tenant Control Planes whose migration has been reverted:
+	// these are not selected again, and they keep using the DataStore.
+	Skipped []string `json:"skipped,omitempty"`
+	// Message reports the reason of the drain failure.
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Driver",type="string",JSONPath=".spec.driver",description="Kamaji data store driver"
+//+kubebuilder:printcolumn:name="Cordoned",type="boolean",JSONPath=".spec.cordoned",description="Cordoned data store",priority=1
+//+kubebuilder:printcolumn:name="Drain",type="string",JSONPath=".status.drain.phase",description="Drain phase",priority=1
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 
 // DataStore is the Schema for the datastores API.
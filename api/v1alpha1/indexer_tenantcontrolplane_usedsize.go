@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneUsedSizeKey = "spec.controlPlane.deployment.size"
+)
+
+type TenantControlPlaneSpecSize struct{}
+
+func (t *TenantControlPlaneSpecSize) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneSpecSize) Field() string {
+	return TenantControlPlaneUsedSizeKey
+}
+
+func (t *TenantControlPlaneSpecSize) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return []string{tcp.Spec.ControlPlane.Deployment.Size}
+	}
+}
+
+func (t *TenantControlPlaneSpecSize) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkPoolSpec defines the addresses and the ports which can be allocated to the Tenant Control Planes.
+type NetworkPoolSpec struct {
+	// Addresses is the list of IP ranges the Tenant Control Plane addresses are allocated from,
+	// expressed in CIDR notation (e.g. 192.168.1.0/28), or as an inclusive range (e.g. 192.168.1.10-192.168.1.20).
+	Addresses []string `json:"addresses,omitempty"`
+	// Ports is the list of port ranges the Tenant Control Plane API Server, and Konnectivity server, ports are allocated from.
+	// When exposing the Tenant Control Planes using NodePort Services, the ranges must be included in the cluster NodePort range.
+	Ports []PortRange `json:"ports,omitempty"`
+	// Default marks the pool to be used by the Tenant Control Planes which are not referring to any pool.
+	Default bool `json:"default,omitempty"`
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	To int32 `json:"to"`
+}
+
+// NetworkPoolAllocation defines the address, and the ports, allocated to a Tenant Control Plane.
+type NetworkPoolAllocation struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address,omitempty"`
+	Ports     []int32 `json:"ports,omitempty"`
+	// AllocatedAt is the allocation time, the allocations of the Tenant Control Planes which have never been created
+	// are released after a grace period.
+	AllocatedAt metav1.Time `json:"allocatedAt"`
+}
+
+// NetworkPoolStatus defines the observed state of NetworkPool.
+type NetworkPoolStatus struct {
+	// Allocations contains the addresses, and the ports, allocated to the Tenant Control Planes.
+	Allocations []NetworkPoolAllocation `json:"allocations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Default",type="boolean",JSONPath=".spec.default",description="Default pool"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// NetworkPool is the Schema for the networkpools API.
+type NetworkPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkPoolSpec   `json:"spec,omitempty"`
+	Status NetworkPoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetworkPoolList contains a list of NetworkPool.
+type NetworkPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkPool{}, &NetworkPoolList{})
+}
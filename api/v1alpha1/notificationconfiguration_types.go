@@ -0,0 +1,84 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NotificationEventStatusChanged       NotificationEvent = "StatusChanged"
+	NotificationEventCertificatesRotated NotificationEvent = "CertificatesRotated"
+	NotificationEventDataStoreMigrated   NotificationEvent = "DataStoreMigrated"
+	NotificationEventDeleted             NotificationEvent = "Deleted"
+)
+
+// +kubebuilder:validation:Enum=StatusChanged;CertificatesRotated;DataStoreMigrated;Deleted
+type NotificationEvent string
+
+// NotificationSink is the HTTP endpoint receiving the CloudEvents, sent using the POST method in structured content mode.
+type NotificationSink struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// CABundle is the PEM encoded bundle used to verify the sink certificate, when empty the system ones are used.
+	CABundle []byte `json:"caBundle,omitempty"`
+	// SigningKey is the key used to sign the CloudEvents using HMAC-SHA256,
+	// the signature of the request body is reported in the X-Kamaji-Signature header.
+	SigningKey *ContentRef `json:"signingKey,omitempty"`
+}
+
+// NotificationRetryPolicy defines the retries of the failed deliveries, such as the network errors,
+// or the sink responding with a 429, or a 5xx, status code.
+type NotificationRetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a failed delivery.
+	//+kubebuilder:default=5
+	//+kubebuilder:validation:Minimum=0
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+	// Backoff is the delay of the first retry, doubled upon each subsequent one.
+	//+kubebuilder:default="1s"
+	Backoff metav1.Duration `json:"backoff,omitempty"`
+	// Timeout is the maximum duration of each delivery attempt.
+	//+kubebuilder:default="10s"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NotificationConfigurationSpec defines the Tenant Control Planes lifecycle events notified to the sink.
+type NotificationConfigurationSpec struct {
+	Sink NotificationSink `json:"sink"`
+	// Events is the list of the notified events, when empty all of them are notified.
+	Events []NotificationEvent `json:"events,omitempty"`
+	// Namespaces restricts the notifications to the Tenant Control Planes of the given namespaces,
+	// when empty the Tenant Control Planes of all the namespaces are notified.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Selector restricts the notifications to the Tenant Control Planes matching the given labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	//+kubebuilder:default={}
+	Retry NotificationRetryPolicy `json:"retry,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Sink",type="string",JSONPath=".spec.sink.url",description="Sink URL"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// NotificationConfiguration is the Schema for the notificationconfigurations API.
+type NotificationConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotificationConfigurationSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NotificationConfigurationList contains a list of NotificationConfiguration.
+type NotificationConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationConfiguration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationConfiguration{}, &NotificationConfigurationList{})
+}
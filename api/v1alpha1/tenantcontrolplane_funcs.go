@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,6 +26,19 @@ func (in *TenantControlPlane) AssignedControlPlaneAddress() (string, int32, erro
 	if len(in.Status.ControlPlaneEndpoint) == 0 {
 		return "", 0, fmt.Errorf("the Tenant Control Plane is not yet exposed")
 	}
+	// When exposed through the SNI proxy the endpoint is referring to the Tenant Control Plane hostname,
+	// although the API Server must advertise the SNI proxy address, and port.
+	if sniProxy := in.Spec.ControlPlane.SNIProxy; sniProxy != nil {
+		return in.Spec.NetworkProfile.Address, sniProxy.Port, nil
+	}
+	// The same applies to the Ingress, although the API Server must advertise the Service address.
+	if ingress := in.Spec.ControlPlane.Ingress; ingress != nil && len(ingress.Hostname) > 0 {
+		if len(in.Status.Kubernetes.Service.Address) == 0 {
+			return "", 0, fmt.Errorf("the Tenant Control Plane Service address is not yet assigned")
+		}
+
+		return in.Status.Kubernetes.Service.Address, in.ExposedPort(), nil
+	}
 
 	address, portString, err := net.SplitHostPort(in.Status.ControlPlaneEndpoint)
 	if err != nil {
@@ -37,6 +53,91 @@ func (in *TenantControlPlane) AssignedControlPlaneAddress() (string, int32, erro
 	return address, int32(port), nil
 }
 
+// GetServicePort returns the port of the Tenant Control Plane Service, defaulting to the API Server one.
+func (in *NetworkProfileSpec) GetServicePort() int32 {
+	if in.ServicePort > 0 {
+		return in.ServicePort
+	}
+
+	return in.Port
+}
+
+// GetNodePort returns the port of the nodes the Tenant Control Plane is exposed on, defaulting to the Service one.
+func (in *NetworkProfileSpec) GetNodePort() int32 {
+	if in.NodePort > 0 {
+		return in.NodePort
+	}
+
+	return in.GetServicePort()
+}
+
+// ExposedPort returns the port the Tenant Control Plane Service is exposed on, according to its type:
+// it's the one announced to the clients, and used in the kubeconfig files.
+func (in *TenantControlPlane) ExposedPort() int32 {
+	if in.Spec.ControlPlane.Service.ServiceType == ServiceTypeNodePort {
+		return in.Spec.NetworkProfile.GetNodePort()
+	}
+
+	return in.Spec.NetworkProfile.GetServicePort()
+}
+
+// KonnectivityHostname returns the hostname used by the Konnectivity agents to reach the Konnectivity server
+// through the SNI proxy, the first label of the hostname is suffixed with "-konnectivity".
+func (in *SNIProxySpec) KonnectivityHostname() string {
+	labels := strings.SplitN(in.Hostname, ".", 2)
+	labels[0] = fmt.Sprintf("%s-konnectivity", labels[0])
+
+	return strings.Join(labels, ".")
+}
+
+// ScaledToZero returns true when the Tenant Control Plane Deployment must be scaled to zero due to inactivity.
+func (in *TenantControlPlane) ScaledToZero() bool {
+	return in.Spec.ControlPlane.IdlePolicy != nil && meta.IsStatusConditionTrue(in.Status.Conditions, ConditionTypeIdle)
+}
+
+// RequiredAddons returns the enabled addons which must be rolled out in the Tenant Cluster
+// for the Tenant Control Plane to be reported as Ready, according to the readiness policy.
+func (in *TenantControlPlane) RequiredAddons() []AddonName {
+	if in.Spec.Addons.ReadinessPolicy == nil {
+		return nil
+	}
+
+	required := make([]AddonName, 0, len(in.Spec.Addons.ReadinessPolicy.Required))
+
+	for _, addon := range in.Spec.Addons.ReadinessPolicy.Required {
+		switch {
+		case addon == AddonCoreDNS && in.Spec.Addons.CoreDNS != nil,
+			addon == AddonKubeProxy && in.Spec.Addons.KubeProxy != nil,
+			addon == AddonKonnectivity && in.Spec.Addons.Konnectivity != nil:
+			required = append(required, addon)
+		}
+	}
+
+	return required
+}
+
+// AvailableStatus returns the status of a Tenant Control Plane whose Deployment is available:
+// Ready, unless the required addons have not yet been rolled out in the Tenant Cluster.
+func (in *TenantControlPlane) AvailableStatus() *KubernetesVersionStatus {
+	if len(in.RequiredAddons()) > 0 && !meta.IsStatusConditionTrue(in.Status.Conditions, ConditionTypeAddonsReady) {
+		return &VersionAddonsNotReady
+	}
+
+	return &VersionReady
+}
+
+// ServedByActivator returns true when the incoming connections must be served by the Kamaji activator,
+// since the Tenant Control Plane is scaled to zero, or it's still waking up.
+func (in *TenantControlPlane) ServedByActivator() bool {
+	if in.Spec.ControlPlane.IdlePolicy == nil {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(in.Status.Conditions, ConditionTypeIdle)
+
+	return condition != nil && (condition.Status == metav1.ConditionTrue || condition.Reason == IdleReasonWakingUp)
+}
+
 // DeclaredControlPlaneAddress returns the desired Tenant Control Plane address.
 // In case of dynamic allocation, e.g. using a Load Balancer, it queries the API Server looking for the allocated IP.
 // When an IP has not been yet assigned, or it is expected, an error is returned.
@@ -70,3 +171,17 @@ func (in *TenantControlPlane) DeclaredControlPlaneAddress(ctx context.Context, c
 
 	return "", kamajierrors.MissingValidIPError{}
 }
+
+// GetControlPlaneSize returns the ControlPlaneSize referred by the Tenant Control Plane, or nil when not referring any.
+func (in *TenantControlPlane) GetControlPlaneSize(ctx context.Context, client client.Client) (*ControlPlaneSize, error) {
+	if len(in.Spec.ControlPlane.Deployment.Size) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	size := &ControlPlaneSize{}
+	if err := client.Get(ctx, types.NamespacedName{Name: in.Spec.ControlPlane.Deployment.Size}, size); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the ControlPlaneSize for the TenantControlPlane")
+	}
+
+	return size, nil
+}
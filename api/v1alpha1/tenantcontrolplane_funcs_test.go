@@ -0,0 +1,223 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTenantControlPlaneIdleState(t *testing.T) {
+	tests := []struct {
+		name              string
+		idlePolicy        *IdlePolicySpec
+		condition         *metav1.Condition
+		scaledToZero      bool
+		servedByActivator bool
+	}{
+		{
+			name: "no idle policy",
+		},
+		{
+			name:       "active",
+			idlePolicy: &IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}},
+			condition:  &metav1.Condition{Type: ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: IdleReasonActive},
+		},
+		{
+			name:              "scaled to zero",
+			idlePolicy:        &IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}},
+			condition:         &metav1.Condition{Type: ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: IdleReasonInactivityTimeoutReached},
+			scaledToZero:      true,
+			servedByActivator: true,
+		},
+		{
+			name:              "waking up",
+			idlePolicy:        &IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}},
+			condition:         &metav1.Condition{Type: ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: IdleReasonWakingUp},
+			servedByActivator: true,
+		},
+		{
+			name:      "idle policy removed while scaled to zero",
+			condition: &metav1.Condition{Type: ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: IdleReasonInactivityTimeoutReached},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &TenantControlPlane{}
+			tcp.Spec.ControlPlane.IdlePolicy = tt.idlePolicy
+
+			if tt.condition != nil {
+				tcp.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+
+			if actual := tcp.ScaledToZero(); actual != tt.scaledToZero {
+				t.Fatalf("expected scaled to zero %t, got %t", tt.scaledToZero, actual)
+			}
+
+			if actual := tcp.ServedByActivator(); actual != tt.servedByActivator {
+				t.Fatalf("expected served by activator %t, got %t", tt.servedByActivator, actual)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneAvailableStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		readinessPolicy *AddonsReadinessPolicy
+		condition       *metav1.Condition
+		expected        KubernetesVersionStatus
+	}{
+		{
+			name:     "no readiness policy",
+			expected: VersionReady,
+		},
+		{
+			name:            "required addon not enabled",
+			readinessPolicy: &AddonsReadinessPolicy{Required: []AddonName{AddonKonnectivity}},
+			expected:        VersionReady,
+		},
+		{
+			name:            "required addons not yet observed",
+			readinessPolicy: &AddonsReadinessPolicy{Required: []AddonName{AddonCoreDNS, AddonKonnectivity}},
+			expected:        VersionAddonsNotReady,
+		},
+		{
+			name:            "required addons progressing",
+			readinessPolicy: &AddonsReadinessPolicy{Required: []AddonName{AddonCoreDNS}},
+			condition:       &metav1.Condition{Type: ConditionTypeAddonsReady, Status: metav1.ConditionFalse, Reason: AddonsReadyReasonProgressing},
+			expected:        VersionAddonsNotReady,
+		},
+		{
+			name:            "required addons rolled out",
+			readinessPolicy: &AddonsReadinessPolicy{Required: []AddonName{AddonCoreDNS}},
+			condition:       &metav1.Condition{Type: ConditionTypeAddonsReady, Status: metav1.ConditionTrue, Reason: AddonsReadyReasonRolledOut},
+			expected:        VersionReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &TenantControlPlane{}
+			tcp.Spec.Addons.CoreDNS = &AddonSpec{}
+			tcp.Spec.Addons.ReadinessPolicy = tt.readinessPolicy
+
+			if tt.condition != nil {
+				tcp.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+
+			if actual := tcp.AvailableStatus(); *actual != tt.expected {
+				t.Fatalf("expected status %s, got %s", tt.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneExposedPort(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceType ServiceType
+		servicePort int32
+		nodePort    int32
+		expected    int32
+	}{
+		{name: "LoadBalancer defaults to the API Server port", serviceType: ServiceTypeLoadBalancer, expected: 6443},
+		{name: "LoadBalancer", serviceType: ServiceTypeLoadBalancer, servicePort: 443, expected: 443},
+		{name: "ClusterIP", serviceType: ServiceTypeClusterIP, servicePort: 443, nodePort: 31443, expected: 443},
+		{name: "NodePort defaults to the API Server port", serviceType: ServiceTypeNodePort, expected: 6443},
+		{name: "NodePort defaults to the Service port", serviceType: ServiceTypeNodePort, servicePort: 443, expected: 443},
+		{name: "NodePort", serviceType: ServiceTypeNodePort, servicePort: 443, nodePort: 31443, expected: 31443},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &TenantControlPlane{}
+			tcp.Spec.ControlPlane.Service.ServiceType = tt.serviceType
+			tcp.Spec.NetworkProfile.Port = 6443
+			tcp.Spec.NetworkProfile.ServicePort = tt.servicePort
+			tcp.Spec.NetworkProfile.NodePort = tt.nodePort
+
+			if actual := tcp.ExposedPort(); actual != tt.expected {
+				t.Fatalf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneAssignedControlPlaneAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		tcp     func(tcp *TenantControlPlane)
+		address string
+		port    int32
+		wantErr bool
+	}{
+		{
+			name:    "not exposed",
+			tcp:     func(tcp *TenantControlPlane) { tcp.Status.ControlPlaneEndpoint = "" },
+			wantErr: true,
+		},
+		{
+			name:    "service",
+			tcp:     func(*TenantControlPlane) {},
+			address: "10.0.0.1",
+			port:    6443,
+		},
+		{
+			name: "sni proxy",
+			tcp: func(tcp *TenantControlPlane) {
+				tcp.Spec.NetworkProfile.Address = "192.168.1.1"
+				tcp.Spec.ControlPlane.SNIProxy = &SNIProxySpec{Hostname: "tenant.example.com", Port: 443}
+				tcp.Status.ControlPlaneEndpoint = "tenant.example.com:443"
+			},
+			address: "192.168.1.1",
+			port:    443,
+		},
+		{
+			name: "ingress",
+			tcp: func(tcp *TenantControlPlane) {
+				tcp.Spec.ControlPlane.Ingress = &IngressSpec{Hostname: "tenant.example.com"}
+				tcp.Status.ControlPlaneEndpoint = "tenant.example.com:6443"
+				tcp.Status.Kubernetes.Service.Address = "10.0.0.1"
+			},
+			address: "10.0.0.1",
+			port:    6443,
+		},
+		{
+			name: "ingress without the Service address",
+			tcp: func(tcp *TenantControlPlane) {
+				tcp.Spec.ControlPlane.Ingress = &IngressSpec{Hostname: "tenant.example.com"}
+				tcp.Status.ControlPlaneEndpoint = "tenant.example.com:6443"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &TenantControlPlane{}
+			tcp.Spec.ControlPlane.Service.ServiceType = ServiceTypeClusterIP
+			tcp.Spec.NetworkProfile.Port = 6443
+			tcp.Status.ControlPlaneEndpoint = "10.0.0.1:6443"
+
+			tt.tcp(tcp)
+
+			address, port, err := tcp.AssignedControlPlaneAddress()
+
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Fatalf("expected error, got %s:%d", address, port)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case address != tt.address || port != tt.port:
+				t.Fatalf("expected %s:%d, got %s:%d", tt.address, tt.port, address, port)
+			}
+		})
+	}
+}
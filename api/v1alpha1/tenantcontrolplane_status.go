@@ -150,6 +150,47 @@ type AddonsStatus struct {
 	CoreDNS      AddonStatus        `json:"coreDNS,omitempty"`
 	KubeProxy    AddonStatus        `json:"kubeProxy,omitempty"`
 	Konnectivity KonnectivityStatus `json:"konnectivity,omitempty"`
+	FlowControl  AddonStatus        `json:"flowControl,omitempty"`
+	// Rollout reports the rollout status of the addons workloads in the Tenant Cluster.
+	Rollout AddonsRolloutStatus `json:"rollout,omitempty"`
+}
+
+// AddonsRolloutStatus reports the rollout status of the enabled addons workloads.
+type AddonsRolloutStatus struct {
+	CoreDNS      *AddonRolloutStatus `json:"coreDNS,omitempty"`
+	KubeProxy    *AddonRolloutStatus `json:"kubeProxy,omitempty"`
+	Konnectivity *AddonRolloutStatus `json:"konnectivity,omitempty"`
+}
+
+// AddonRolloutStatus reports the rollout status of an addon workload, such as the CoreDNS Deployment, or the kube-proxy DaemonSet.
+type AddonRolloutStatus struct {
+	// Ready is true when the latest workload revision has been rolled out, and all its replicas are available.
+	Ready bool `json:"ready"`
+	// DesiredReplicas is the number of the desired replicas, or of the nodes which should run the DaemonSet pod.
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// UpdatedReplicas is the number of the replicas running the latest workload revision.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+	// AvailableReplicas is the number of the available replicas.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// Message reports why the workload is not yet rolled out.
+	Message string `json:"message,omitempty"`
+}
+
+// LeaderElectionStatus contains the leaders of the Control Plane components, as reported by the Leases in the Tenant Cluster.
+type LeaderElectionStatus struct {
+	ControllerManager ComponentLeaderStatus `json:"controllerManager,omitempty"`
+	Scheduler         ComponentLeaderStatus `json:"scheduler,omitempty"`
+}
+
+type ComponentLeaderStatus struct {
+	// Pod is the name of the Tenant Control Plane pod running the leader.
+	Pod string `json:"pod,omitempty"`
+	// HolderIdentity is the identity of the Lease holder.
+	HolderIdentity string `json:"holderIdentity,omitempty"`
+	// LeaseTransitions is the number of transitions of the Lease between holders.
+	LeaseTransitions int32 `json:"leaseTransitions,omitempty"`
+	// LastTransitionTime is the time the current leader acquired the Lease.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // TenantControlPlaneStatus defines the observed state of TenantControlPlane.
@@ -171,8 +212,51 @@ type TenantControlPlaneStatus struct {
 	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
 	// Addons contains the status of the different Addons
 	Addons AddonsStatus `json:"addons,omitempty"`
+	// LeaderElection reports the current leaders of the Control Plane components.
+	LeaderElection LeaderElectionStatus `json:"leaderElection,omitempty"`
+	// Conditions contains the latest observations of the Tenant Control Plane state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ConditionTypeEndpointReachable reports if the advertised Tenant Control Plane endpoint is serving the API Server
+	// with a certificate signed by the Tenant Control Plane CA, and the readiness endpoint is healthy.
+	ConditionTypeEndpointReachable = "EndpointReachable"
+
+	EndpointReachableReasonProbeSucceeded       = "ProbeSucceeded"
+	EndpointReachableReasonNotAdvertised        = "EndpointNotAdvertised"
+	EndpointReachableReasonUnreachable          = "EndpointUnreachable"
+	EndpointReachableReasonTLSVerificationError = "TLSVerificationFailed"
+	EndpointReachableReasonNotReady             = "APIServerNotReady"
+	EndpointReachableReasonCAUnavailable        = "CertificateAuthorityUnavailable"
+	EndpointReachableReasonSleeping             = "Sleeping"
+
+	// ConditionTypeIdle reports if the Tenant Control Plane has been scaled to zero due to inactivity,
+	// according to the idle policy: in such case, the incoming connections are served by the Kamaji activator.
+	ConditionTypeIdle = "Idle"
+
+	IdleReasonInactivityTimeoutReached = "InactivityTimeoutReached"
+	IdleReasonWakingUp                 = "WakingUp"
+	IdleReasonActive                   = "Active"
+
+	// ConditionTypeReconciled reports if the last reconciliation of the Tenant Control Plane resources has been completed,
+	// or the resource which didn't complete within its timeout, such as a hanging DataStore query.
+	ConditionTypeReconciled = "Reconciled"
+
+	ReconciledReasonSucceeded       = "ReconciliationSucceeded"
+	ReconciledReasonResourceTimeout = "ResourceTimeout"
+
+	// ConditionTypeAddonsReady reports if the addons required by the readiness policy have been rolled out in the Tenant Cluster,
+	// as observed by Kamaji: until then, the Tenant Control Plane status is reported as AddonsNotReady.
+	ConditionTypeAddonsReady = "AddonsReady"
+
+	AddonsReadyReasonRolledOut   = "AddonsRolledOut"
+	AddonsReadyReasonProgressing = "AddonsProgressing"
+	AddonsReadyReasonNotRequired = "NoAddonsRequired"
+)
+
 // KubernetesStatus defines the status of the resources deployed in the management cluster,
 // such as Deployment and Service.
 type KubernetesStatus struct {
@@ -183,16 +267,18 @@ type KubernetesStatus struct {
 	Ingress    *KubernetesIngressStatus   `json:"ingress,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;NotReady
+// +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;AddonsNotReady;NotReady;Sleeping
 type KubernetesVersionStatus string
 
 var (
-	VersionProvisioning KubernetesVersionStatus = "Provisioning"
-	VersionCARotating   KubernetesVersionStatus = "CertificateAuthorityRotating"
-	VersionUpgrading    KubernetesVersionStatus = "Upgrading"
-	VersionMigrating    KubernetesVersionStatus = "Migrating"
-	VersionReady        KubernetesVersionStatus = "Ready"
-	VersionNotReady     KubernetesVersionStatus = "NotReady"
+	VersionProvisioning   KubernetesVersionStatus = "Provisioning"
+	VersionCARotating     KubernetesVersionStatus = "CertificateAuthorityRotating"
+	VersionUpgrading      KubernetesVersionStatus = "Upgrading"
+	VersionMigrating      KubernetesVersionStatus = "Migrating"
+	VersionReady          KubernetesVersionStatus = "Ready"
+	VersionAddonsNotReady KubernetesVersionStatus = "AddonsNotReady"
+	VersionNotReady       KubernetesVersionStatus = "NotReady"
+	VersionSleeping       KubernetesVersionStatus = "Sleeping"
 )
 
 type KubernetesVersion struct {
@@ -212,6 +298,8 @@ type KubernetesDeploymentStatus struct {
 	Name string `json:"name"`
 	// The namespace which the Deployment for the given cluster is deployed.
 	Namespace string `json:"namespace"`
+	// Size is the name of the ControlPlaneSize applied to the Deployment, if any.
+	Size string `json:"size,omitempty"`
 	// Last time when deployment was updated
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
@@ -225,6 +313,9 @@ type KubernetesServiceStatus struct {
 	Namespace string `json:"namespace"`
 	// The port where the service is running
 	Port int32 `json:"port"`
+	// Address is the IP address the Service is reachable at, advertised by the API Server
+	// when the Tenant Control Plane is announced using the Ingress hostname.
+	Address string `json:"address,omitempty"`
 }
 
 // KubernetesIngressStatus defines the status for the Tenant Control Plane Ingress in the management cluster.
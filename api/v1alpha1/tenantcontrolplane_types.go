@@ -17,9 +17,23 @@ type NetworkProfileSpec struct {
 	// AllowAddressAsExternalIP will include tenantControlPlane.Spec.NetworkProfile.Address in the section of
 	// ExternalIPs of the Kubernetes Service (only ClusterIP or NodePort)
 	AllowAddressAsExternalIP bool `json:"allowAddressAsExternalIP,omitempty"`
-	// Port where API server of will be exposed
-	// +kubebuilder:default=6443
+	// Port where API server of will be exposed.
+	// If empty, it's allocated from the NetworkPool, or defaulted to 6443 when no pool is used.
+	// It's the API Server secure port, also used by the Service, and as NodePort, unless specified otherwise.
 	Port int32 `json:"port,omitempty"`
+	// ServicePort is the port of the Tenant Control Plane Service, announced to the clients when exposed using a
+	// ClusterIP, or a LoadBalancer, Service. If empty, the API Server port is used.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ServicePort int32 `json:"servicePort,omitempty"`
+	// NodePort is the port of the nodes the Tenant Control Plane is exposed on, announced to the clients when using a NodePort
+	// Service: it must be included in the NodePort range of the Management Cluster. If empty, the Service port is used.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	NodePort int32 `json:"nodePort,omitempty"`
+	// Pool is the name of the NetworkPool the address, and the ports, are allocated from when left empty.
+	// If not specified, the default NetworkPool is used, if any.
+	Pool string `json:"pool,omitempty"`
 	// CertSANs sets extra Subject Alternative Names (SANs) for the API Server signing certificate.
 	// Use this field to add additional hostnames when exposing the Tenant Control Plane with third solutions.
 	CertSANs []string `json:"certSANs,omitempty"`
@@ -65,6 +79,12 @@ type KubernetesSpec struct {
 	// Full reference available here: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers
 	// +kubebuilder:default=CertificateApproval;CertificateSigning;CertificateSubjectRestriction;DefaultIngressClass;DefaultStorageClass;DefaultTolerationSeconds;LimitRanger;MutatingAdmissionWebhook;NamespaceLifecycle;PersistentVolumeClaimResize;Priority;ResourceQuota;RuntimeClass;ServiceAccount;StorageObjectInUseProtection;TaintNodesByCondition;ValidatingAdmissionWebhook
 	AdmissionControllers AdmissionControllers `json:"admissionControllers,omitempty"`
+	// Partial kubeadm ClusterConfiguration, in YAML or JSON format using the kubeadm.k8s.io/v1beta3 API,
+	// merged into the one generated by Kamaji before being stored in the kubeadm config ConfigMap, and uploaded to the Tenant cluster.
+	// The fields managed by Kamaji, such as the control plane endpoint, the etcd, the certificates directory, the networking,
+	// the Kubernetes version, and the cluster name, cannot be changed.
+	// The certificate SANs are added to the generated ones.
+	ClusterConfiguration string `json:"clusterConfiguration,omitempty"`
 }
 
 // AdditionalMetadata defines which additional metadata, such as labels and annotations, must be attached to the created resource.
@@ -82,6 +102,31 @@ type ControlPlane struct {
 	Service ServiceSpec `json:"service"`
 	// Defining the options for an Optional Ingress which will expose API Server of the Tenant Control Plane
 	Ingress *IngressSpec `json:"ingress,omitempty"`
+	// Defining the options to expose the API Server of the Tenant Control Plane through the shared Kamaji SNI proxy,
+	// allowing multiple Tenant Control Planes to be served by a single load balancer.
+	SNIProxy *SNIProxySpec `json:"sniProxy,omitempty"`
+	// Defining the options to scale the Tenant Control Plane to zero replicas upon inactivity:
+	// the Kamaji activator is holding the incoming connections, and scaling the Tenant Control Plane back up.
+	IdlePolicy *IdlePolicySpec `json:"idlePolicy,omitempty"`
+}
+
+// IdlePolicySpec defines the scale to zero of the Tenant Control Plane when no API requests are received.
+type IdlePolicySpec struct {
+	// IdleTimeout is the duration with no API requests after which the Tenant Control Plane Deployment is scaled to zero.
+	// The watch requests, and the ones related to leases, events, and non resource URLs, are not considered as activity.
+	IdleTimeout metav1.Duration `json:"idleTimeout"`
+}
+
+// SNIProxySpec defines the options to expose the Tenant Control Plane through the shared Kamaji SNI proxy.
+// The TLS connections are routed with no termination to the Tenant Control Plane Service according to the
+// Server Name Indication: the API Server is reachable using the provided hostname, the Konnectivity server
+// using the hostname with the first label suffixed by "-konnectivity".
+type SNIProxySpec struct {
+	// Hostname used to reach the Tenant Control Plane API Server through the SNI proxy.
+	// +kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+	// Port where the SNI proxy is exposed, if empty, the default one specified in the Kamaji setup will be used.
+	Port int32 `json:"port,omitempty"`
 }
 
 // IngressSpec defines the options for the ingress which will expose API Server of the Tenant Control Plane.
@@ -89,7 +134,7 @@ type IngressSpec struct {
 	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
 	IngressClassName   string             `json:"ingressClassName,omitempty"`
 	// Hostname is an optional field which will be used as Ingress's Host. If it is not defined,
-	// Ingress's host will be "<tenant>.<namespace>.<domain>", where domain is specified under NetworkProfileSpec
+	// Ingress's host will be "<tenant>.<namespace>.<domain>", where domain is the Ingress base domain configured in Kamaji.
 	Hostname string `json:"hostname,omitempty"`
 }
 
@@ -107,7 +152,11 @@ type DeploymentSpec struct {
 	// It could be used to point to a different container registry rather than the public one.
 	// +kubebuilder:default={registry:"registry.k8s.io",apiServerImage:"kube-apiserver",controllerManagerImage:"kube-controller-manager",schedulerImage:"kube-scheduler"}
 	RegistrySettings RegistrySettings `json:"registrySettings,omitempty"`
-	// +kubebuilder:default=2
+	// Size is the name of the ControlPlaneSize used as preset for the replicas, the resources, and the arguments
+	// of the Control Plane components: the values declared in the Tenant Control Plane specification take precedence.
+	Size string `json:"size,omitempty"`
+	// Replicas is the number of the Tenant Control Plane Pods, when not specified the ControlPlaneSize one is used,
+	// and it defaults to 2 otherwise.
 	Replicas *int32 `json:"replicas,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
@@ -150,6 +199,21 @@ type DeploymentSpec struct {
 	// AdditionalVolumeMounts allows to mount an additional volume into each component of the Control Plane
 	// (kube-apiserver, controller-manager, and scheduler).
 	AdditionalVolumeMounts *AdditionalVolumeMounts `json:"additionalVolumeMounts,omitempty"`
+	// LeaderElection defines the lease settings of the controller-manager, and the scheduler,
+	// when not specified the components defaults are used.
+	LeaderElection *LeaderElectionSpec `json:"leaderElection,omitempty"`
+}
+
+// LeaderElectionSpec defines the leader election settings of the Control Plane components:
+// the lease duration must be greater than the renew deadline, which must be greater than the retry period.
+type LeaderElectionSpec struct {
+	// LeaseDuration is the duration that non-leader candidates will wait after observing a leadership renewal
+	// until attempting to acquire leadership of a led but unrenewed leader slot.
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is the interval between attempts by the acting leader to renew a leadership slot before it stops leading.
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is the duration the clients should wait between attempting acquisition and renewal of a leadership.
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
 }
 
 // AdditionalVolumeMounts allows mounting additional volumes to the Control Plane components.
@@ -193,7 +257,8 @@ type ExtraArgs []string
 
 type KonnectivityServerSpec struct {
 	// The port which Konnectivity server is listening to.
-	Port int32 `json:"port"`
+	// If empty, it's allocated from the NetworkPool, or defaulted to 8132 when no pool is used.
+	Port int32 `json:"port,omitempty"`
 	// Container image version of the Konnectivity server.
 	// +kubebuilder:default=v0.0.32
 	Version string `json:"version,omitempty"`
@@ -217,10 +282,43 @@ type KonnectivityAgentSpec struct {
 
 // KonnectivitySpec defines the spec for Konnectivity.
 type KonnectivitySpec struct {
-	// +kubebuilder:default={version:"v0.0.32",image:"registry.k8s.io/kas-network-proxy/proxy-server",port:8132}
+	// +kubebuilder:default={version:"v0.0.32",image:"registry.k8s.io/kas-network-proxy/proxy-server"}
 	KonnectivityServerSpec KonnectivityServerSpec `json:"server,omitempty"`
 	// +kubebuilder:default={version:"v0.0.32",image:"registry.k8s.io/kas-network-proxy/proxy-agent"}
 	KonnectivityAgentSpec KonnectivityAgentSpec `json:"agent,omitempty"`
+	// EgressSelections defines the routing of the API Server traffic per destination, used to generate the
+	// EgressSelectorConfiguration: the cluster traffic is routed through Konnectivity, unless declared otherwise,
+	// while the undeclared controlplane and etcd destinations are reached directly.
+	// Any change triggers a rollout of the Tenant Control Plane.
+	EgressSelections []EgressSelection `json:"egressSelections,omitempty"`
+}
+
+// EgressSelectionName is the destination of the API Server traffic, as defined by the EgressSelectorConfiguration:
+// cluster is the traffic toward the nodes, pods, and services of the Tenant Cluster, such as the admission webhooks
+// and the aggregated APIs, controlplane the one toward the control plane, and etcd the one toward the DataStore.
+// +kubebuilder:validation:Enum=cluster;controlplane;etcd
+type EgressSelectionName string
+
+const (
+	EgressSelectionCluster      EgressSelectionName = "cluster"
+	EgressSelectionControlPlane EgressSelectionName = "controlplane"
+	EgressSelectionEtcd         EgressSelectionName = "etcd"
+)
+
+// EgressSelectionType is the way the API Server reaches a destination.
+// +kubebuilder:validation:Enum=Direct;Konnectivity
+type EgressSelectionType string
+
+const (
+	EgressSelectionTypeDirect       EgressSelectionType = "Direct"
+	EgressSelectionTypeKonnectivity EgressSelectionType = "Konnectivity"
+)
+
+type EgressSelection struct {
+	Name EgressSelectionName `json:"name"`
+	// Type is Direct when the destination is reached by the API Server network, such as the management cluster services,
+	// or Konnectivity when it must be tunnelled through the Konnectivity agents running in the Tenant Cluster.
+	Type EgressSelectionType `json:"type"`
 }
 
 // AddonsSpec defines the enabled addons and their features.
@@ -233,6 +331,59 @@ type AddonsSpec struct {
 	// Enables the kube-proxy addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `kube-proxy`.
 	KubeProxy *AddonSpec `json:"kubeProxy,omitempty"`
+	// Enables the API Priority and Fairness defaults in the Tenant Cluster, reserving the API Server capacity
+	// to the given clients, such as the kubelets, protecting them from noisy tenant workloads.
+	// The API Server in-flight requests limits are sized according to its CPU requests, unless set using the extra args.
+	// Requires Kubernetes v1.26, or greater.
+	FlowControl *FlowControlSpec `json:"flowControl,omitempty"`
+	// ReadinessPolicy defines the addons which must be rolled out in the Tenant Cluster for the Tenant Control Plane
+	// to be reported as Ready: until then, its status is reported as AddonsNotReady.
+	// When not set, the readiness depends on the Control Plane Deployment only.
+	ReadinessPolicy *AddonsReadinessPolicy `json:"readinessPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=CoreDNS;KubeProxy;Konnectivity
+type AddonName string
+
+const (
+	AddonCoreDNS      AddonName = "CoreDNS"
+	AddonKubeProxy    AddonName = "KubeProxy"
+	AddonKonnectivity AddonName = "Konnectivity"
+)
+
+// AddonsReadinessPolicy defines the addons gating the Tenant Control Plane readiness.
+type AddonsReadinessPolicy struct {
+	// Required is the list of the addons whose workloads must be rolled out, with all their replicas available,
+	// before reporting the Tenant Control Plane as Ready. The addons not enabled are ignored.
+	// +listType=set
+	Required []AddonName `json:"required,omitempty"`
+}
+
+// FlowControlSpec defines the PriorityLevelConfiguration and FlowSchema resources seeded in the Tenant Cluster.
+type FlowControlSpec struct {
+	// PriorityLevels are the priority levels created in the Tenant Cluster, each one along with the FlowSchema
+	// classifying the requests of its subjects. When empty, Kamaji reserves capacity to the kubelets,
+	// including their heartbeats, and to the control plane components managed by Kamaji.
+	PriorityLevels []FlowControlPriorityLevel `json:"priorityLevels,omitempty"`
+}
+
+type FlowControlPriorityLevel struct {
+	// Name of the PriorityLevelConfiguration, and of the FlowSchema, prefixed with kamaji- in the Tenant Cluster.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// NominalConcurrencyShares is the share of the API Server concurrency limit reserved to the priority level.
+	// +kubebuilder:validation:Minimum=1
+	NominalConcurrencyShares int32 `json:"nominalConcurrencyShares"`
+	// MatchingPrecedence of the FlowSchema, the lower values are evaluated first:
+	// Kubernetes default FlowSchemas are starting from 100, apart from the exempt ones.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	MatchingPrecedence int32 `json:"matchingPrecedence"`
+	// Users whose requests are classified into the priority level,
+	// the service accounts are referred as system:serviceaccount:<namespace>:<name>.
+	Users []string `json:"users,omitempty"`
+	// Groups whose requests are classified into the priority level.
+	Groups []string `json:"groups,omitempty"`
 }
 
 // TenantControlPlaneSpec defines the desired state of TenantControlPlane.
@@ -240,14 +391,46 @@ type TenantControlPlaneSpec struct {
 	// DataStore allows to specify a DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
 	// This parameter is optional and acts as an override over the default one which is used by the Kamaji Operator.
 	// Migration from a different DataStore to another one is not yet supported and the reconciliation will be blocked.
-	DataStore    string       `json:"dataStore,omitempty"`
-	ControlPlane ControlPlane `json:"controlPlane"`
+	DataStore string `json:"dataStore,omitempty"`
+	// DataStoreUserPolicy overrides the restrictions applied to the datastore user of the Tenant Control Plane,
+	// the fields not set here are inherited from the DataStore ones.
+	DataStoreUserPolicy *DataStoreUserPolicy `json:"dataStoreUserPolicy,omitempty"`
+	ControlPlane        ControlPlane         `json:"controlPlane"`
 	// Kubernetes specification for tenant control plane
 	Kubernetes KubernetesSpec `json:"kubernetes"`
 	// NetworkProfile specifies how the network is
 	NetworkProfile NetworkProfileSpec `json:"networkProfile,omitempty"`
 	// Addons contain which addons are enabled
 	Addons AddonsSpec `json:"addons,omitempty"`
+	// AdditionalMetadata defines the labels and annotations propagated to all the resources created for the
+	// Tenant Control Plane, such as Secrets, ConfigMaps, and the Konnectivity agent in the Tenant Cluster:
+	// the ones defined for the Deployment, the Service, and the Ingress, take precedence.
+	// The labels and annotations prefixed with kamaji.clastix.io/ are reserved.
+	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
+	// Patches are applied, in the declared order, to the resources generated by Kamaji for the Tenant Control Plane,
+	// allowing to customize the fields not available in the API: the owner references, and the selector labels,
+	// cannot be modified.
+	Patches []Patch `json:"patches,omitempty"`
+}
+
+// Patch defines a patch applied to a resource generated for the Tenant Control Plane,
+// once the Kamaji desired state has been computed.
+type Patch struct {
+	Target PatchTarget `json:"target"`
+	// Type is the patch type, either a JSON patch (RFC 6902), or a strategic merge patch.
+	// +kubebuilder:default=StrategicMerge
+	Type PatchType `json:"type,omitempty"`
+	// Patch is the patch body, in JSON or YAML format: a list of operations for the JSON patches,
+	// or a partial object for the strategic merge ones.
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// PatchTarget selects the resource generated for the Tenant Control Plane the patch is applied to.
+type PatchTarget struct {
+	Kind PatchTargetKind `json:"kind"`
+	// Name of the targeted resource, if empty, the resource of the given kind generated for the Tenant Control Plane is selected.
+	Name string `json:"name,omitempty"`
 }
 
 // +kubebuilder:object:root=true
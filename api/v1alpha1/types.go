@@ -35,3 +35,20 @@ const (
 
 // +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
 type ServiceType corev1.ServiceType
+
+const (
+	PatchTargetDeployment PatchTargetKind = "Deployment"
+	PatchTargetService    PatchTargetKind = "Service"
+	PatchTargetIngress    PatchTargetKind = "Ingress"
+)
+
+// +kubebuilder:validation:Enum=Deployment;Service;Ingress
+type PatchTargetKind string
+
+const (
+	PatchTypeJSON           PatchType = "JSON"
+	PatchTypeStrategicMerge PatchType = "StrategicMerge"
+)
+
+// +kubebuilder:validation:Enum=JSON;StrategicMerge
+type PatchType string
@@ -89,3 +89,10 @@ Create the name of the cert-manager Certificate
 {{- define "kamaji.certificateName" -}}
 {{- printf "%s-serving-cert" (include "kamaji.fullname" .) }}
 {{- end }}
+
+{{/*
+Create the name of the SNI proxy resources
+*/}}
+{{- define "kamaji.sniProxyName" -}}
+{{- printf "%s-sni-proxy" (include "kamaji.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
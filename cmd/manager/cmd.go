@@ -12,12 +12,16 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -26,8 +30,12 @@ import (
 	"github.com/clastix/kamaji/controllers"
 	"github.com/clastix/kamaji/controllers/soot"
 	"github.com/clastix/kamaji/internal"
+	"github.com/clastix/kamaji/internal/activator"
 	"github.com/clastix/kamaji/internal/builders/controlplane"
 	datastoreutils "github.com/clastix/kamaji/internal/datastore/utils"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/images"
+	"github.com/clastix/kamaji/internal/notifications"
 	"github.com/clastix/kamaji/internal/webhook"
 	"github.com/clastix/kamaji/internal/webhook/handlers"
 	"github.com/clastix/kamaji/internal/webhook/routes"
@@ -43,6 +51,9 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		tmpDirectory               string
 		kineImage                  string
 		controllerReconcileTimeout time.Duration
+		resourceTimeout            time.Duration
+		resourceTimeoutsFlag       map[string]string
+		resourceTimeouts           map[string]time.Duration
 		cacheResyncPeriod          time.Duration
 		datastore                  string
 		managerNamespace           string
@@ -51,6 +62,26 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		webhookCABundle            []byte
 		migrateJobImage            string
 		maxConcurrentReconciles    int
+		endpointProbeInterval      time.Duration
+		endpointProbeTimeout       time.Duration
+		sniProxyAddress            string
+		sniProxyPort               int32
+		ingressBaseDomain          string
+		activatorAddress           string
+		activatorWakeUpTimeout     time.Duration
+		idleTrackingInterval       time.Duration
+		networkPoolGracePeriod     time.Duration
+		nodePortRange              = utilnet.PortRange{Base: 30000, Size: 2768}
+		scheduling                 controlplane.Scheduling
+		registryRewriteFlag        map[string]string
+		imageDigestsFile           string
+		imagePolicy                images.Policy
+		joinTokenTTL               time.Duration
+		gitOpsRegistration         gitops.Registration
+
+		tcpRateLimiter                  controllers.RateLimiterConfig
+		certificateLifecycleRateLimiter controllers.RateLimiterConfig
+		dataStoreRateLimiter            controllers.RateLimiterConfig
 
 		webhookCAPath string
 	)
@@ -83,6 +114,35 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return fmt.Errorf("the controller reconcile timeout must be greater than zero")
 			}
 
+			resourceTimeouts = make(map[string]time.Duration, len(resourceTimeoutsFlag))
+			for name, value := range resourceTimeoutsFlag {
+				if resourceTimeouts[name], err = time.ParseDuration(value); err != nil {
+					return fmt.Errorf("unable to parse the %s resource timeout: %w", name, err)
+				}
+			}
+
+			if imagePolicy.Rules, err = images.ParseRules(registryRewriteFlag); err != nil {
+				return err
+			}
+
+			if len(imageDigestsFile) > 0 {
+				if imagePolicy.Digests, err = images.LoadDigests(imageDigestsFile); err != nil {
+					return err
+				}
+			}
+
+			if joinTokenTTL.Seconds() <= 0 {
+				return fmt.Errorf("the join token TTL must be greater than zero")
+			}
+
+			if gitOpsRegistration.Enabled() && len(gitOpsRegistration.ClusterRole) == 0 {
+				return fmt.Errorf("the GitOps cluster role is required to register the Tenant Clusters")
+			}
+
+			if len(activatorAddress) > 0 && (idleTrackingInterval.Seconds() == 0 || activatorWakeUpTimeout.Seconds() == 0) {
+				return fmt.Errorf("the idle tracking interval, and the activator wake up timeout, must be greater than zero")
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -107,6 +167,12 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 					return cache.New(config, opts)
 				},
+				// Avoiding the caching of the whole cluster Pods and Endpoints,
+				// only retrieved for the Tenant Control Planes with an idle policy.
+				ClientDisableCacheFor: []client.Object{
+					&corev1.Pod{},
+					&corev1.Endpoints{},
+				},
 			})
 			if err != nil {
 				setupLog.Error(err, "unable to start manager")
@@ -116,12 +182,36 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 			tcpChannel, certChannel := make(controllers.TenantControlPlaneChannel), make(controllers.CertificateChannel)
 
-			if err = (&controllers.DataStore{TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+			if err = (&controllers.DataStore{TenantControlPlaneTrigger: tcpChannel, RateLimiter: dataStoreRateLimiter, KamajiNamespace: managerNamespace}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DataStore")
 
 				return err
 			}
 
+			var tcpActivator *activator.Activator
+			// The idle policies are enforced only if the activator can be reached by the Tenant Control Plane Services.
+			if len(activatorAddress) > 0 {
+				tcpActivator = &activator.Activator{
+					Client:        mgr.GetClient(),
+					Address:       activatorAddress,
+					WakeUpTimeout: activatorWakeUpTimeout,
+					DialTimeout:   5 * time.Second,
+					Log:           ctrl.Log.WithName("activator"),
+				}
+
+				if err = mgr.Add(tcpActivator); err != nil {
+					setupLog.Error(err, "unable to add the activator")
+
+					return err
+				}
+
+				if err = (&controllers.IdleTracker{Client: mgr.GetClient(), Interval: idleTrackingInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "IdleTracker")
+
+					return err
+				}
+			}
+
 			reconciler := &controllers.TenantControlPlaneReconciler{
 				Client:    mgr.GetClient(),
 				APIReader: mgr.GetAPIReader(),
@@ -130,6 +220,11 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					DefaultDataStoreName: datastore,
 					KineContainerImage:   kineImage,
 					TmpBaseDirectory:     tmpDirectory,
+					ResourceTimeout:      resourceTimeout,
+					ResourceTimeouts:     resourceTimeouts,
+					Scheduling:           scheduling,
+					Images:               imagePolicy,
+					GitOps:               gitOpsRegistration,
 				},
 				CertificateChan:         certChannel,
 				TriggerChan:             tcpChannel,
@@ -138,6 +233,8 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				KamajiService:           managerServiceName,
 				KamajiMigrateImage:      migrateJobImage,
 				MaxConcurrentReconciles: maxConcurrentReconciles,
+				RateLimiter:             tcpRateLimiter,
+				Activator:               tcpActivator,
 			}
 
 			if err = reconciler.SetupWithManager(mgr); err != nil {
@@ -146,12 +243,30 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
-			if err = (&controllers.CertificateLifecycle{Channel: certChannel}).SetupWithManager(mgr); err != nil {
+			if err = (&controllers.CertificateLifecycle{Channel: certChannel, RateLimiter: certificateLifecycleRateLimiter}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CertificateLifecycle")
 
 				return err
 			}
 
+			if err = (&controllers.NetworkPool{Client: mgr.GetClient(), GracePeriod: networkPoolGracePeriod}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "NetworkPool")
+
+				return err
+			}
+
+			if endpointProbeInterval > 0 {
+				if err = (&controllers.EndpointProbe{
+					Client:   mgr.GetClient(),
+					Interval: endpointProbeInterval,
+					Timeout:  endpointProbeTimeout,
+				}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "EndpointProbe")
+
+					return err
+				}
+			}
+
 			if err = (&kamajiv1alpha1.DatastoreUsedSecret{}).SetupWithManager(ctx, mgr); err != nil {
 				setupLog.Error(err, "unable to create indexer", "indexer", "DatastoreUsedSecret")
 
@@ -164,29 +279,65 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&kamajiv1alpha1.TenantControlPlaneSpecSize{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneSpecSize")
+
+				return err
+			}
+
 			err = webhook.Register(mgr, map[routes.Route][]handlers.Handler{
 				routes.TenantControlPlaneMigrate{}: {
 					handlers.Freeze{},
 				},
 				routes.TenantControlPlaneDefaults{}: {
-					handlers.TenantControlPlaneDefaults{DefaultDatastore: datastore},
+					handlers.TenantControlPlaneDefaults{Client: mgr.GetClient(), DefaultDatastore: datastore},
+					handlers.TenantControlPlaneSNIProxyDefaults{Address: sniProxyAddress, Port: sniProxyPort},
+					handlers.TenantControlPlaneIngressDefaults{BaseDomain: ingressBaseDomain},
+					handlers.TenantControlPlaneNetworkPool{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneImageDigests{Images: imagePolicy, KineImage: kineImage, MigrateImage: migrateJobImage},
 				},
 				routes.TenantControlPlaneValidate{}: {
 					handlers.TenantControlPlaneName{},
 					handlers.TenantControlPlaneVersion{},
 					handlers.TenantControlPlaneKubeletAddresses{},
 					handlers.TenantControlPlaneDataStore{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneSize{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneSNIProxy{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneIngress{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneIdlePolicy{},
+					handlers.TenantControlPlanePriority{},
+					handlers.TenantControlPlaneFlowControl{},
+					handlers.TenantControlPlaneEgressSelector{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneLeaderElection{},
+					handlers.TenantControlPlaneNetworkProfile{NodePortRange: nodePortRange},
+					handlers.TenantControlPlaneAdditionalMetadata{},
+					handlers.TenantControlPlaneClusterConfiguration{},
+					handlers.TenantControlPlanePatches{
+						Client: mgr.GetClient(),
+						DeploymentBuilder: controlplane.Deployment{
+							Client:             mgr.GetClient(),
+							KineContainerImage: kineImage,
+							Scheduling:         scheduling,
+							Images:             imagePolicy,
+						},
+					},
 					handlers.TenantControlPlaneDeployment{
 						Client: mgr.GetClient(),
 						DeploymentBuilder: controlplane.Deployment{
 							Client:             mgr.GetClient(),
 							KineContainerImage: kineImage,
+							Scheduling:         scheduling,
+							Images:             imagePolicy,
 						},
 						KonnectivityBuilder: controlplane.Konnectivity{
 							Scheme: *mgr.GetScheme(),
+							Images: imagePolicy,
 						},
 					},
 				},
+				routes.ControlPlaneSizeValidate{}: {
+					handlers.ControlPlaneSizeValidation{Client: mgr.GetClient()},
+				},
 				routes.DataStoreValidate{}: {
 					handlers.DataStoreValidation{Client: mgr.GetClient()},
 				},
@@ -205,12 +356,21 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				MigrateServiceName:      managerServiceName,
 				MigrateServiceNamespace: managerNamespace,
 				AdminClient:             mgr.GetClient(),
+				Images:                  imagePolicy,
+				JoinTokenTTL:            joinTokenTTL,
+				GitOps:                  gitOpsRegistration,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to set up soot manager")
 
 				return err
 			}
 
+			if err = (&notifications.Dispatcher{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to set up the notifications dispatcher")
+
+				return err
+			}
+
 			if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 				setupLog.Error(err, "unable to set up health check")
 
@@ -255,7 +415,32 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().StringVar(&managerServiceAccountName, "serviceaccount-name", os.Getenv("SERVICE_ACCOUNT"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&webhookCAPath, "webhook-ca-path", "/tmp/k8s-webhook-server/serving-certs/ca.crt", "Path to the Manager webhook server CA, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().DurationVar(&controllerReconcileTimeout, "controller-reconcile-timeout", 30*time.Second, "The reconciliation request timeout before the controller withdraw the external resource calls, such as dealing with the Datastore, or the Tenant Control Plane API endpoint.")
+	cmd.Flags().DurationVar(&resourceTimeout, "resource-timeout", 0, "The maximum duration of the handling of a single Tenant Control Plane resource, such as the DataStore setup, setting it to zero bounds it by the controller reconcile timeout only.")
+	cmd.Flags().StringToStringVar(&resourceTimeoutsFlag, "resource-timeouts", map[string]string{}, "The timeouts overriding the resource one for the given Tenant Control Plane resources, e.g. datastore-setup=10s,upgrade=20s.")
+	cmd.Flags().DurationVar(&endpointProbeInterval, "endpoint-probe-interval", time.Minute, "The interval between the reachability probes of the advertised Tenant Control Plane endpoints, setting it to zero disables the probes.")
+	cmd.Flags().DurationVar(&endpointProbeTimeout, "endpoint-probe-timeout", 5*time.Second, "The timeout of a single reachability probe of the advertised Tenant Control Plane endpoint.")
+	cmd.Flags().StringVar(&sniProxyAddress, "sni-proxy-address", "", "The IP address of the shared SNI proxy, used as default advertised address by the Tenant Control Planes exposed through it.")
+	cmd.Flags().Int32Var(&sniProxyPort, "sni-proxy-port", 6443, "The port of the shared SNI proxy, used as default by the Tenant Control Planes exposed through it.")
+	cmd.Flags().StringVar(&ingressBaseDomain, "ingress-base-domain", "", "The base domain used to generate the hostname of the Tenant Control Planes exposed using an Ingress without specifying it, as <name>.<namespace>.<base domain>.")
+	cmd.Flags().StringVar(&activatorAddress, "activator-address", os.Getenv("POD_IP"), "The IP address of the Kamaji instance serving the connections of the Tenant Control Planes scaled to zero, setting it to empty disables the idle policies.")
+	cmd.Flags().DurationVar(&activatorWakeUpTimeout, "activator-wake-up-timeout", 2*time.Minute, "The maximum duration a connection is held by the activator, waiting for the Tenant Control Plane to be woken up.")
+	cmd.Flags().DurationVar(&idleTrackingInterval, "idle-tracking-interval", time.Minute, "The interval between the collections of the API requests metrics of the Tenant Control Planes with an idle policy.")
+	cmd.Flags().Var(&nodePortRange, "service-node-port-range", "The NodePort range of the Management Cluster, the NodePort declared by the Tenant Control Planes must be included in.")
+	cmd.Flags().DurationVar(&networkPoolGracePeriod, "network-pool-allocation-grace-period", time.Minute, "The grace period before releasing the network pool allocations of the Tenant Control Planes which have not been created, e.g. due to a rejected admission.")
+	cmd.Flags().BoolVar(&scheduling.ZoneSpread, "control-plane-zone-spread", false, "Spread the replicas of the Tenant Control Planes across the zones, unless these are declaring their own topology spread constraints.")
+	cmd.Flags().BoolVar(&scheduling.HostnameSpread, "control-plane-hostname-spread", false, "Spread the replicas of the Tenant Control Planes across the nodes, unless these are declaring their own topology spread constraints.")
+	cmd.Flags().BoolVar(&scheduling.AntiAffinity, "control-plane-anti-affinity", false, "Prefer to schedule the replicas of the Tenant Control Planes on different nodes, unless these are declaring their own affinity.")
+	cmd.Flags().BoolVar(&scheduling.DataStoreSpread, "control-plane-datastore-spread", false, "Prefer to schedule the Tenant Control Planes sharing the same DataStore in different zones, unless these are declaring their own affinity.")
+	cmd.Flags().StringToStringVar(&registryRewriteFlag, "registry-rewrite", map[string]string{}, "The registry rewrite rules applied to every image rendered by Kamaji, e.g. registry.k8s.io=mirror.local/k8s,docker.io=mirror.local/hub: the most specific prefix wins.")
+	cmd.Flags().StringVar(&imageDigestsFile, "image-digests-file", "", "Path to the local mirror manifest index, a YAML map of the rewritten images to their digest, used to pin the Tenant Control Plane images upon admission.")
+	cmd.Flags().DurationVar(&joinTokenTTL, "join-token-ttl", time.Hour, "The validity of the bootstrap tokens created in the Tenant Clusters upon the request of the worker nodes join artifacts.")
+	cmd.Flags().StringVar(&gitOpsRegistration.ArgoCDNamespace, "gitops-argocd-namespace", "", "The namespace where the Argo CD cluster Secrets of the ready Tenant Control Planes are created, setting it to empty disables the Argo CD registration.")
+	cmd.Flags().StringVar(&gitOpsRegistration.FluxNamespace, "gitops-flux-namespace", "", "The namespace where the Flux kubeconfig Secrets of the ready Tenant Control Planes are created, setting it to empty disables the Flux registration.")
+	cmd.Flags().StringVar(&gitOpsRegistration.ClusterRole, "gitops-cluster-role", "", "The Tenant Cluster ClusterRole bound to the identity used by the GitOps tools, required when the registration is enabled.")
 	cmd.Flags().DurationVar(&cacheResyncPeriod, "cache-resync-period", 10*time.Hour, "The controller-runtime.Manager cache resync period.")
+	rateLimiterFlags(cmd.Flags(), "tenant-control-plane", "Tenant Control Plane", &tcpRateLimiter)
+	rateLimiterFlags(cmd.Flags(), "certificate-lifecycle", "Certificate Lifecycle", &certificateLifecycleRateLimiter)
+	rateLimiterFlags(cmd.Flags(), "datastore", "DataStore", &dataStoreRateLimiter)
 
 	cobra.OnInitialize(func() {
 		viper.AutomaticEnv()
@@ -263,3 +448,13 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 	return cmd
 }
+
+// rateLimiterFlags binds the rate limiter settings of the given controller to the CLI flags.
+func rateLimiterFlags(flags *pflag.FlagSet, prefix, controller string, config *controllers.RateLimiterConfig) {
+	defaults := controllers.DefaultRateLimiterConfig
+
+	flags.DurationVar(&config.BaseDelay, prefix+"-rate-limiter-base-delay", defaults.BaseDelay, fmt.Sprintf("The base delay of the exponential backoff applied upon the requeue of the %s controller failed reconciliations.", controller))
+	flags.DurationVar(&config.MaxDelay, prefix+"-rate-limiter-max-delay", defaults.MaxDelay, fmt.Sprintf("The maximum delay of the exponential backoff applied upon the requeue of the %s controller failed reconciliations.", controller))
+	flags.Float64Var(&config.QPS, prefix+"-rate-limiter-qps", defaults.QPS, fmt.Sprintf("The overall rate of the requeued reconciliations allowed for the %s controller.", controller))
+	flags.IntVar(&config.Burst, prefix+"-rate-limiter-burst", defaults.Burst, fmt.Sprintf("The burst of the requeued reconciliations allowed for the %s controller.", controller))
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package sniproxy
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/clastix/kamaji/controllers"
+	"github.com/clastix/kamaji/internal/sniproxy"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		metricsBindAddress     string
+		healthProbeBindAddress string
+		listenAddress          string
+		handshakeTimeout       time.Duration
+		dialTimeout            time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:          "sni-proxy",
+		Short:        "Start the TLS passthrough proxy exposing the Tenant Control Planes according to the Server Name Indication",
+		SilenceUsage: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if handshakeTimeout.Seconds() == 0 || dialTimeout.Seconds() == 0 {
+				return fmt.Errorf("the handshake and dial timeouts must be greater than zero")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			setupLog := ctrl.Log.WithName("setup")
+
+			mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+				Scheme:                 scheme,
+				MetricsBindAddress:     metricsBindAddress,
+				HealthProbeBindAddress: healthProbeBindAddress,
+				// Each replica of the SNI proxy must serve the connections, and keep its own routing table.
+				LeaderElection: false,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to start manager")
+
+				return err
+			}
+
+			router := sniproxy.NewRouter()
+
+			if err = (&controllers.SNIProxyRoutes{Client: mgr.GetClient(), Router: router}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "SNIProxyRoutes")
+
+				return err
+			}
+
+			if err = mgr.Add(&sniproxy.Proxy{
+				Router:           router,
+				ListenAddress:    listenAddress,
+				HandshakeTimeout: handshakeTimeout,
+				DialTimeout:      dialTimeout,
+				Log:              ctrl.Log.WithName("sni-proxy"),
+			}); err != nil {
+				setupLog.Error(err, "unable to add the SNI proxy")
+
+				return err
+			}
+
+			if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+				setupLog.Error(err, "unable to set up health check")
+
+				return err
+			}
+			if err = mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+				setupLog.Error(err, "unable to set up ready check")
+
+				return err
+			}
+
+			setupLog.Info("starting SNI proxy")
+			if err = mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+				setupLog.Error(err, "problem running SNI proxy")
+
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	// Setting zap logger
+	zapfs := flag.NewFlagSet("zap", flag.ExitOnError)
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(zapfs)
+	cmd.Flags().AddGoFlagSet(zapfs)
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// Setting CLI flags
+	cmd.Flags().StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	cmd.Flags().StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	cmd.Flags().StringVar(&listenAddress, "listen-address", ":6443", "The address the SNI proxy is accepting the TLS connections on.")
+	cmd.Flags().DurationVar(&handshakeTimeout, "handshake-timeout", 10*time.Second, "The maximum duration to receive the TLS ClientHello message from the clients.")
+	cmd.Flags().DurationVar(&dialTimeout, "dial-timeout", 5*time.Second, "The maximum duration to establish the connection to the Tenant Control Plane Service.")
+
+	return cmd
+}
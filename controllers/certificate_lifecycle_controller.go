@@ -13,25 +13,33 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/priorityqueue"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
 type CertificateLifecycle struct {
 	Channel CertificateChannel
-	client  client.Client
+	// RateLimiter is applied upon the requeue of the requests.
+	RateLimiter RateLimiterConfig
+
+	client client.Client
 }
 
 func (s *CertificateLifecycle) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -139,10 +147,16 @@ func (s *CertificateLifecycle) extractCertificateFromKubeconfig(secret corev1.Se
 func (s *CertificateLifecycle) SetupWithManager(mgr controllerruntime.Manager) error {
 	s.client = mgr.GetClient()
 
+	queue := priorityqueue.New("certificatelifecycle", 1, s.priority)
+	if err := mgr.Add(queue); err != nil {
+		return err
+	}
+
 	supportedStrategies := sets.New[string]("x509", "kubeconfig")
 
 	return controllerruntime.NewControllerManagedBy(mgr).
-		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		Named("secret").
+		Watches(&source.Kind{Type: &corev1.Secret{}}, queue.Handler(&handler.EnqueueRequestForObject{}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
 			labels := object.GetLabels()
 
 			if labels == nil {
@@ -156,5 +170,23 @@ func (s *CertificateLifecycle) SetupWithManager(mgr controllerruntime.Manager) e
 
 			return supportedStrategies.Has(value)
 		}))).
-		Complete(s)
+		WithOptions(controller.Options{
+			RateLimiter: s.RateLimiter.RateLimiter(),
+		}).
+		Complete(queue.Reconciler(s))
+}
+
+// priority returns the priority class of the Tenant Control Plane owning the Secret.
+func (s *CertificateLifecycle) priority(ctx context.Context, request reconcile.Request) priorityqueue.Class {
+	secret := corev1.Secret{}
+	if err := s.client.Get(ctx, request.NamespacedName, &secret); err != nil || len(secret.GetOwnerReferences()) == 0 {
+		return priorityqueue.ClassNormal
+	}
+
+	tcp := kamajiv1alpha1.TenantControlPlane{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: secret.GetNamespace(), Name: secret.GetOwnerReferences()[0].Name}, &tcp); err != nil {
+		return priorityqueue.ClassNormal
+	}
+
+	return priorityqueue.ClassFor(&tcp)
 }
@@ -5,6 +5,7 @@ package controllers
 
 import (
 	"context"
+	"sync"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
@@ -14,6 +15,7 @@ import (
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/priorityqueue"
 )
 
 type DataStore struct {
@@ -30,6 +33,16 @@ type DataStore struct {
 	// if a Data Source is updated we have to be sure that the reconciliation of the certificates content
 	// for each Tenant Control Plane is put in place properly.
 	TenantControlPlaneTrigger TenantControlPlaneChannel
+	// RateLimiter is applied upon the requeue of the failed reconciliations.
+	RateLimiter RateLimiterConfig
+	// KamajiNamespace is the namespace where the migration Jobs are created, checked upon a DataStore drain.
+	KamajiNamespace string
+
+	mu sync.Mutex
+	// reconciledVersions is the resource version of each DataStore upon its last status update:
+	// the Tenant Control Planes are triggered only when the DataStore has been changed since then,
+	// rather than upon each requeue of the drain, or of the maintenance.
+	reconciledVersions map[string]string
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
@@ -41,6 +54,9 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 	ds := &kamajiv1alpha1.DataStore{}
 	if err := r.client.Get(ctx, request.NamespacedName, ds); err != nil {
 		if k8serrors.IsNotFound(err) {
+			deleteEtcdMetrics(request.Name, true)
+			r.setReconciledVersion(request.Name, "")
+
 			return reconcile.Result{}, nil
 		}
 
@@ -65,20 +81,86 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	ds.Status.UsedBy = tcpSets.List()
+	// The drain status is retained until the drain is removed from the specification.
+	var result reconcile.Result
+	var drainErr error
+
+	if ds.Spec.Drain != nil {
+		result, drainErr = r.drain(ctx, ds, tcpList.Items)
+	} else {
+		ds.Status.Drain = nil
+	}
+
+	var maintenanceResult reconcile.Result
+	var maintenanceErr error
+
+	if ds.Spec.Driver == kamajiv1alpha1.EtcdDriver && ds.Spec.EtcdMaintenance != nil {
+		maintenanceResult, maintenanceErr = r.maintenance(ctx, ds)
+	} else {
+		ds.Status.Etcd = nil
+
+		deleteEtcdMetrics(ds.GetName(), true)
+	}
+
+	changed := r.reconciledVersion(ds.GetName()) != ds.GetResourceVersion()
 
 	if err := r.client.Status().Update(ctx, ds); err != nil {
 		log.Error(err, "cannot update the status for the given instance")
 
 		return reconcile.Result{}, err
 	}
+
+	r.setReconciledVersion(ds.GetName(), ds.GetResourceVersion())
 	// Triggering the reconciliation of the Tenant Control Plane upon a Secret change
-	for _, i := range tcpList.Items {
-		tcp := i
+	if changed {
+		for _, i := range tcpList.Items {
+			tcp := i
+
+			r.TenantControlPlaneTrigger <- event.GenericEvent{Object: &tcp}
+		}
+	}
+
+	if drainErr != nil {
+		log.Error(drainErr, "cannot drain the given instance")
+
+		return reconcile.Result{}, drainErr
+	}
+
+	if maintenanceErr != nil {
+		log.Error(maintenanceErr, "cannot perform the maintenance of the given instance")
+
+		return reconcile.Result{}, maintenanceErr
+	}
+	// Requeueing upon the earliest check required by the drain, or the maintenance.
+	if result.RequeueAfter == 0 || (maintenanceResult.RequeueAfter > 0 && maintenanceResult.RequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = maintenanceResult.RequeueAfter
+	}
+
+	return result, nil
+}
 
-		r.TenantControlPlaneTrigger <- event.GenericEvent{Object: &tcp}
+func (r *DataStore) reconciledVersion(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reconciledVersions[name]
+}
+
+func (r *DataStore) setReconciledVersion(name, resourceVersion string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(resourceVersion) == 0 {
+		delete(r.reconciledVersions, name)
+
+		return
 	}
 
-	return reconcile.Result{}, nil
+	if r.reconciledVersions == nil {
+		r.reconciledVersions = make(map[string]string)
+	}
+
+	r.reconciledVersions[name] = resourceVersion
 }
 
 func (r *DataStore) InjectClient(client client.Client) error {
@@ -88,6 +170,11 @@ func (r *DataStore) InjectClient(client client.Client) error {
 }
 
 func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
+	queue := priorityqueue.New("datastore", 1, r.priority)
+	if err := mgr.Add(queue); err != nil {
+		return err
+	}
+
 	enqueueFn := func(tcp *kamajiv1alpha1.TenantControlPlane, limitingInterface workqueue.RateLimitingInterface) {
 		if dataStoreName := tcp.Status.Storage.DataStoreName; len(dataStoreName) > 0 {
 			limitingInterface.AddRateLimited(reconcile.Request{
@@ -99,10 +186,12 @@ func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
 	}
 	//nolint:forcetypeassert
 	return controllerruntime.NewControllerManagedBy(mgr).
-		For(&kamajiv1alpha1.DataStore{}, builder.WithPredicates(
-			predicate.ResourceVersionChangedPredicate{},
+		Named("datastore").
+		// The status updates are ignored, since the maintenance one is changing at each reconciliation.
+		Watches(&source.Kind{Type: &kamajiv1alpha1.DataStore{}}, queue.Handler(&handler.EnqueueRequestForObject{}), builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
 		)).
-		Watches(&source.Kind{Type: &kamajiv1alpha1.TenantControlPlane{}}, handler.Funcs{
+		Watches(&source.Kind{Type: &kamajiv1alpha1.TenantControlPlane{}}, queue.Handler(handler.Funcs{
 			CreateFunc: func(createEvent event.CreateEvent, limitingInterface workqueue.RateLimitingInterface) {
 				enqueueFn(createEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
 			},
@@ -113,6 +202,30 @@ func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
 			DeleteFunc: func(deleteEvent event.DeleteEvent, limitingInterface workqueue.RateLimitingInterface) {
 				enqueueFn(deleteEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
 			},
+		})).
+		WithOptions(controller.Options{
+			RateLimiter: r.RateLimiter.RateLimiter(),
 		}).
-		Complete(r)
+		Complete(queue.Reconciler(r))
+}
+
+// priority returns the highest priority class among the Tenant Control Planes using the DataStore:
+// its reconciliation is triggering the Tenant Control Planes one, upon a change.
+func (r *DataStore) priority(ctx context.Context, request reconcile.Request) priorityqueue.Class {
+	tcpList := kamajiv1alpha1.TenantControlPlaneList{}
+	if err := r.client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, request.Name),
+	}); err != nil {
+		return priorityqueue.ClassNormal
+	}
+
+	class := priorityqueue.ClassLow
+
+	for i := range tcpList.Items {
+		if tcpClass := priorityqueue.ClassFor(&tcpList.Items[i]); tcpClass.Higher(class) {
+			class = tcpClass
+		}
+	}
+
+	return class
 }
@@ -0,0 +1,73 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDataStoreTenantControlPlaneTrigger(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	dataStore := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	dataStore.Spec.Driver = kamajiv1alpha1.KineMySQLDriver
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+	tcp.Status.Storage.DataStoreName = "default"
+
+	indexer := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dataStore, tcp).WithIndex(indexer.Object(), indexer.Field(), indexer.ExtractValue()).Build()
+
+	trigger := make(TenantControlPlaneChannel, 10)
+	r := &DataStore{client: c, TenantControlPlaneTrigger: trigger}
+
+	reconcileDataStore := func(triggered int) {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "default"}}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if len(trigger) != triggered {
+			t.Fatalf("expected %d Tenant Control Planes to be triggered, got %d", triggered, len(trigger))
+		}
+
+		for len(trigger) > 0 {
+			<-trigger
+		}
+	}
+
+	reconcileDataStore(1)
+	// The requeues are not triggering the Tenant Control Planes, unless the DataStore has been changed.
+	reconcileDataStore(0)
+
+	if err := c.Get(ctx, k8stypes.NamespacedName{Name: "default"}, dataStore); err != nil {
+		t.Fatal(err)
+	}
+
+	dataStore.Spec.Endpoints = []string{"mysql:3306"}
+
+	if err := c.Update(ctx, dataStore); err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileDataStore(1)
+}
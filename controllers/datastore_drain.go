@@ -0,0 +1,189 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	ds "github.com/clastix/kamaji/internal/resources/datastore"
+)
+
+// drainCheckInterval is the interval used to check the progress of the migration upon a DataStore drain:
+// the migration Job failures are not triggering the reconciliation of the DataStore.
+const drainCheckInterval = 30 * time.Second
+
+// drain migrates the Tenant Control Planes using the DataStore to the drain target, one at a time,
+// reporting the progress in the DataStore status, which is updated by the caller.
+func (r *DataStore) drain(ctx context.Context, dataStore *kamajiv1alpha1.DataStore, tcps []kamajiv1alpha1.TenantControlPlane) (reconcile.Result, error) {
+	status := dataStore.Status.Drain
+	if status == nil || status.TargetDataStore != dataStore.Spec.Drain.TargetDataStore {
+		status = &kamajiv1alpha1.DataStoreDrainStatus{TargetDataStore: dataStore.Spec.Drain.TargetDataStore}
+		dataStore.Status.Drain = status
+	}
+
+	status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseDraining, ""
+	// The Tenant Control Planes are selected by the DataStore reported in their status:
+	// the migrated ones are not listed anymore.
+	attached := make(map[string]*kamajiv1alpha1.TenantControlPlane, len(tcps))
+	for i := range tcps {
+		attached[getNamespacedName(tcps[i].GetNamespace(), tcps[i].GetName()).String()] = &tcps[i]
+	}
+
+	if len(status.Current) > 0 {
+		current, ok := attached[status.Current]
+
+		switch {
+		case !ok:
+			if err := r.completeMigration(ctx, status); err != nil {
+				return reconcile.Result{}, err
+			}
+		case current.Spec.DataStore == dataStore.GetName():
+			// The migration has been reverted, such as by the tenant owner:
+			// the Tenant Control Plane is skipped, rather than being migrated again.
+			status.Skipped = append(status.Skipped, status.Current)
+			status.Current = ""
+		default:
+			message, err := r.migrationFailure(ctx, current)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			if len(message) > 0 {
+				status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseFailed, fmt.Sprintf("the migration of %s failed: %s", status.Current, message)
+			}
+
+			return reconcile.Result{RequeueAfter: drainCheckInterval}, nil
+		}
+	}
+
+	if len(attached) == 0 {
+		status.Phase = kamajiv1alpha1.DataStoreDrainPhaseDrained
+
+		return reconcile.Result{}, nil
+	}
+
+	if message, err := r.drainTargetIssue(ctx, dataStore); err != nil || len(message) > 0 {
+		status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseFailed, message
+
+		return reconcile.Result{RequeueAfter: drainCheckInterval}, err
+	}
+
+	skipped := sets.NewString(status.Skipped...)
+
+	names := make([]string, 0, len(attached))
+	for name := range attached {
+		if !skipped.Has(name) {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseFailed, fmt.Sprintf("the migration of %s has been reverted", strings.Join(skipped.List(), ", "))
+
+		return reconcile.Result{RequeueAfter: drainCheckInterval}, nil
+	}
+
+	sort.Strings(names)
+
+	next := names[0]
+	// A Tenant Control Plane could be already migrating, such as upon a manual change:
+	// waiting for its completion, since the migrations are performed one at a time.
+	for _, name := range names {
+		if attached[name].Spec.DataStore != dataStore.GetName() {
+			next = name
+
+			break
+		}
+	}
+
+	if tcp := attached[next]; tcp.Spec.DataStore == dataStore.GetName() {
+		patch := client.MergeFrom(tcp.DeepCopy())
+		tcp.Spec.DataStore = status.TargetDataStore
+
+		if err := r.client.Patch(ctx, tcp, patch); err != nil {
+			status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseFailed, fmt.Sprintf("the migration of %s cannot be started: %s", next, err.Error())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	status.Current = next
+
+	return reconcile.Result{RequeueAfter: drainCheckInterval}, nil
+}
+
+// completeMigration records the Tenant Control Plane currently migrated as completed, once it's not using the DataStore anymore:
+// the deleted ones are ignored.
+func (r *DataStore) completeMigration(ctx context.Context, status *kamajiv1alpha1.DataStoreDrainStatus) error {
+	namespace, name, _ := strings.Cut(status.Current, "/")
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, tcp); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if tcp.Status.Storage.DataStoreName == status.TargetDataStore {
+		status.Migrated = append(status.Migrated, status.Current)
+	}
+
+	status.Current = ""
+
+	return nil
+}
+
+// migrationFailure returns the failure message of the migration Job of the given Tenant Control Plane,
+// which is empty if the Job has not failed.
+func (r *DataStore) migrationFailure(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Namespace: r.KamajiNamespace, Name: ds.MigrateJobName(tcp)}, job); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition.Message, nil
+		}
+	}
+
+	return "", nil
+}
+
+// drainTargetIssue returns the reason why the Tenant Control Planes cannot be migrated to the drain target,
+// which could have been changed after the drain validation.
+func (r *DataStore) drainTargetIssue(ctx context.Context, dataStore *kamajiv1alpha1.DataStore) (string, error) {
+	target := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: dataStore.Spec.Drain.TargetDataStore}, target); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Sprintf("the target DataStore %s does not exist", dataStore.Spec.Drain.TargetDataStore), nil
+		}
+
+		return fmt.Sprintf("the target DataStore %s cannot be retrieved", dataStore.Spec.Drain.TargetDataStore), err
+	}
+
+	switch {
+	case target.Spec.Cordoned:
+		return fmt.Sprintf("the target DataStore %s is cordoned", target.GetName()), nil
+	case target.Spec.Driver != dataStore.Spec.Driver:
+		return fmt.Sprintf("the target DataStore %s uses the %s driver instead of %s", target.GetName(), target.Spec.Driver, dataStore.Spec.Driver), nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDataStoreDrain(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	origin := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "origin"}}
+	origin.Spec.Driver = kamajiv1alpha1.KineMySQLDriver
+	origin.Spec.Cordoned = true
+	origin.Spec.Drain = &kamajiv1alpha1.DataStoreDrainSpec{TargetDataStore: "target"}
+
+	target := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "target"}}
+	target.Spec.Driver = kamajiv1alpha1.KineMySQLDriver
+
+	tenantControlPlane := func(name string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		tcp.Spec.DataStore = "origin"
+		tcp.Status.Storage.DataStoreName = "origin"
+
+		return tcp
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(origin, target, tenantControlPlane("b"), tenantControlPlane("a")).Build()
+	r := &DataStore{client: c, KamajiNamespace: "kamaji-system"}
+
+	attached := func() []kamajiv1alpha1.TenantControlPlane {
+		tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+		if err := c.List(ctx, tcpList); err != nil {
+			t.Fatal(err)
+		}
+
+		var tcps []kamajiv1alpha1.TenantControlPlane
+
+		for _, tcp := range tcpList.Items {
+			if tcp.Status.Storage.DataStoreName == "origin" {
+				tcps = append(tcps, tcp)
+			}
+		}
+
+		return tcps
+	}
+
+	update := func(name string, fn func(tcp *kamajiv1alpha1.TenantControlPlane)) {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		if err := c.Get(ctx, k8stypes.NamespacedName{Namespace: "default", Name: name}, tcp); err != nil {
+			t.Fatal(err)
+		}
+
+		fn(tcp)
+
+		if err := c.Update(ctx, tcp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrated := func(name string) {
+		update(name, func(tcp *kamajiv1alpha1.TenantControlPlane) {
+			if tcp.Spec.DataStore != "target" {
+				t.Fatalf("expected %s to be migrating to the target DataStore, got %s", name, tcp.Spec.DataStore)
+			}
+
+			tcp.Status.Storage.DataStoreName = "target"
+		})
+	}
+
+	drain := func(phase kamajiv1alpha1.DataStoreDrainPhase, current string, migrated ...string) {
+		if _, err := r.drain(ctx, origin, attached()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		status := origin.Status.Drain
+		if status.Phase != phase || status.Current != current || len(status.Migrated) != len(migrated) {
+			t.Fatalf("unexpected drain status %+v", status)
+		}
+
+		for i := range migrated {
+			if status.Migrated[i] != migrated[i] {
+				t.Fatalf("unexpected migrated Tenant Control Planes %v", status.Migrated)
+			}
+		}
+	}
+
+	drain(kamajiv1alpha1.DataStoreDrainPhaseDraining, "default/a")
+	// The migrations are performed one at a time.
+	drain(kamajiv1alpha1.DataStoreDrainPhaseDraining, "default/a")
+
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "migrate-default-a"}}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "backoff limit exceeded"}}
+
+	if err := c.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+
+	drain(kamajiv1alpha1.DataStoreDrainPhaseFailed, "default/a")
+
+	if err := c.Delete(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated("a")
+	drain(kamajiv1alpha1.DataStoreDrainPhaseDraining, "default/b", "default/a")
+
+	// The reverted migrations are skipped, rather than being started again.
+	update("b", func(tcp *kamajiv1alpha1.TenantControlPlane) {
+		tcp.Spec.DataStore = "origin"
+	})
+	drain(kamajiv1alpha1.DataStoreDrainPhaseFailed, "", "default/a")
+
+	if skipped := origin.Status.Drain.Skipped; len(skipped) != 1 || skipped[0] != "default/b" {
+		t.Fatalf("unexpected skipped Tenant Control Planes %v", skipped)
+	}
+
+	update("b", func(tcp *kamajiv1alpha1.TenantControlPlane) {
+		if tcp.Spec.DataStore != "origin" {
+			t.Fatalf("expected the skipped Tenant Control Plane not to be migrated, got %s", tcp.Spec.DataStore)
+		}
+
+		tcp.Spec.DataStore = "target"
+	})
+	migrated("b")
+	drain(kamajiv1alpha1.DataStoreDrainPhaseDrained, "", "default/a")
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(target), target); err != nil {
+		t.Fatal(err)
+	}
+
+	target.Spec.Cordoned = true
+	origin.Status.Drain = nil
+
+	if err := c.Update(ctx, target); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Create(ctx, tenantControlPlane("c")); err != nil {
+		t.Fatal(err)
+	}
+
+	drain(kamajiv1alpha1.DataStoreDrainPhaseFailed, "")
+}
@@ -0,0 +1,256 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+const (
+	// etcdMaintenanceCheckInterval is the interval used to collect the status of the etcd members.
+	etcdMaintenanceCheckInterval = 5 * time.Minute
+	// etcdMaintenanceStepInterval is the interval between the defragmentation of two members,
+	// letting the defragmented one catch up with the cluster.
+	etcdMaintenanceStepInterval = time.Minute
+	// etcdDefragmentationTimeout is the maximum duration of a member defragmentation.
+	etcdDefragmentationTimeout = 5 * time.Minute
+)
+
+var (
+	etcdDBSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_datastore_etcd_db_size_bytes",
+		Help: "Size of the etcd member database, including the space not in use.",
+	}, []string{"datastore", "endpoint"})
+	etcdDBSizeInUse = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_datastore_etcd_db_size_in_use_bytes",
+		Help: "Size of the etcd member database in use.",
+	}, []string{"datastore", "endpoint"})
+	etcdFragmentation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_datastore_etcd_db_fragmentation_ratio",
+		Help: "Ratio of the etcd member database not in use, reclaimed by the defragmentation.",
+	}, []string{"datastore", "endpoint"})
+	etcdDefragmentations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kamaji_datastore_etcd_defragmentations_total",
+		Help: "Number of the etcd member defragmentations performed by Kamaji, by result.",
+	}, []string{"datastore", "endpoint", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(etcdDBSize, etcdDBSizeInUse, etcdFragmentation, etcdDefragmentations)
+}
+
+// etcdMaintenance is implemented by the etcd DataStore connection.
+type etcdMaintenance interface {
+	Endpoints() []string
+	MemberStatus(ctx context.Context, endpoint string) (*datastore.EtcdMemberStatus, error)
+	Alarms(ctx context.Context) (map[uint64][]string, error)
+	Compact(ctx context.Context, revision int64) error
+	Defragment(ctx context.Context, endpoint string) error
+	DisarmNoSpaceAlarm(ctx context.Context, memberID uint64) error
+}
+
+// maintenance performs the maintenance of the etcd DataStore members,
+// reporting their status in the DataStore one, which is updated by the caller.
+func (r *DataStore) maintenance(ctx context.Context, dataStore *kamajiv1alpha1.DataStore) (reconcile.Result, error) {
+	connection, err := datastore.NewStorageConnection(ctx, r.client, *dataStore)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer connection.Close()
+
+	etcd, ok := connection.(etcdMaintenance)
+	if !ok {
+		return reconcile.Result{}, fmt.Errorf("the %s driver doesn't support the maintenance", connection.Driver())
+	}
+
+	return maintainEtcd(ctx, dataStore, etcd, time.Now())
+}
+
+// maintainEtcd collects the status of the etcd members, defragmenting at most one of them:
+// the members raising a NOSPACE alarm have precedence, and the leader is the last one,
+// since its defragmentation is blocking the whole cluster.
+func maintainEtcd(ctx context.Context, dataStore *kamajiv1alpha1.DataStore, etcd etcdMaintenance, now time.Time) (reconcile.Result, error) {
+	spec := dataStore.Spec.EtcdMaintenance
+
+	alarms, err := etcd.Alarms(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	lastDefragmentations := make(map[string]*metav1.Time)
+	if dataStore.Status.Etcd != nil {
+		for _, member := range dataStore.Status.Etcd.Members {
+			lastDefragmentations[member.Endpoint] = member.LastDefragmentation
+		}
+	}
+
+	status := &kamajiv1alpha1.EtcdMaintenanceStatus{LastUpdate: metav1.NewTime(now)}
+	dataStore.Status.Etcd = status
+
+	defer observeEtcdMembers(dataStore.GetName(), status)
+
+	members := make(map[string]*datastore.EtcdMemberStatus)
+
+	for _, endpoint := range etcd.Endpoints() {
+		member := kamajiv1alpha1.EtcdMemberStatus{Endpoint: endpoint, LastDefragmentation: lastDefragmentations[endpoint]}
+
+		current, memberErr := etcd.MemberStatus(ctx, endpoint)
+		if memberErr != nil {
+			member.Message = memberErr.Error()
+		} else {
+			members[endpoint] = current
+			setEtcdMemberStatus(&member, current, alarms[current.MemberID])
+		}
+
+		status.Members = append(status.Members, member)
+	}
+	// Defragmenting a member while another one is not reachable could make the cluster lose the quorum.
+	if len(members) < len(status.Members) {
+		return reconcile.Result{RequeueAfter: etcdMaintenanceCheckInterval}, nil
+	}
+	// The members are paced by the stored defragmentations, rather than by the requeues,
+	// since the reconciliation can be triggered earlier, such as upon a DataStore change.
+	for _, member := range status.Members {
+		if last := member.LastDefragmentation; last != nil && now.Sub(last.Time) < etcdMaintenanceStepInterval {
+			return reconcile.Result{RequeueAfter: etcdMaintenanceStepInterval - now.Sub(last.Time)}, nil
+		}
+	}
+
+	index, noSpace := -1, false
+
+	for i, member := range status.Members {
+		current := members[member.Endpoint]
+
+		memberNoSpace := spec.DisarmAlarms && hasEtcdAlarm(member.Alarms, datastore.EtcdNoSpaceAlarm)
+		due := (member.LastDefragmentation == nil || now.Sub(member.LastDefragmentation.Time) >= spec.DefragmentationInterval.Duration) &&
+			current.DBSize-current.DBSizeInUse >= spec.DefragmentationThreshold.Value()
+
+		switch {
+		case !memberNoSpace && !due:
+			continue
+		case index < 0, memberNoSpace && !noSpace, !noSpace && members[status.Members[index].Endpoint].IsLeader:
+			index, noSpace = i, memberNoSpace
+		}
+	}
+
+	if index < 0 {
+		return reconcile.Result{RequeueAfter: etcdMaintenanceCheckInterval}, nil
+	}
+
+	member := &status.Members[index]
+	current := members[member.Endpoint]
+
+	if noSpace {
+		// The API Servers cannot compact the keyspace while the alarm is raised,
+		// since they're storing the compacted revision in etcd.
+		if err = etcd.Compact(ctx, current.Revision); err != nil {
+			member.Message = err.Error()
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	defragmentationCtx, cancel := context.WithTimeout(ctx, etcdDefragmentationTimeout)
+	defer cancel()
+
+	if err = etcd.Defragment(defragmentationCtx, member.Endpoint); err != nil {
+		etcdDefragmentations.WithLabelValues(dataStore.GetName(), member.Endpoint, "failure").Inc()
+		member.Message = err.Error()
+
+		return reconcile.Result{}, err
+	}
+
+	etcdDefragmentations.WithLabelValues(dataStore.GetName(), member.Endpoint, "success").Inc()
+	member.LastDefragmentation = &metav1.Time{Time: now}
+
+	if noSpace {
+		if err = etcd.DisarmNoSpaceAlarm(ctx, current.MemberID); err != nil {
+			member.Message = err.Error()
+
+			return reconcile.Result{}, err
+		}
+
+		delete(alarms, current.MemberID)
+
+		for _, alarm := range member.Alarms {
+			if alarm != datastore.EtcdNoSpaceAlarm {
+				alarms[current.MemberID] = append(alarms[current.MemberID], alarm)
+			}
+		}
+	}
+	// Refreshing the member database size, the failure is reported upon the next collection.
+	if current, err = etcd.MemberStatus(ctx, member.Endpoint); err == nil {
+		setEtcdMemberStatus(member, current, alarms[current.MemberID])
+	}
+
+	return reconcile.Result{RequeueAfter: etcdMaintenanceStepInterval}, nil
+}
+
+func setEtcdMemberStatus(member *kamajiv1alpha1.EtcdMemberStatus, current *datastore.EtcdMemberStatus, alarms []string) {
+	member.MemberID = strconv.FormatUint(current.MemberID, 16)
+	member.DBSize, member.DBSizeInUse = current.DBSize, current.DBSizeInUse
+	member.Alarms = alarms
+
+	member.Fragmentation = 0
+	if current.DBSize > 0 {
+		member.Fragmentation = int32((current.DBSize - current.DBSizeInUse) * 100 / current.DBSize)
+	}
+}
+
+func hasEtcdAlarm(alarms []string, alarm string) bool {
+	for _, i := range alarms {
+		if i == alarm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// observeEtcdMembers exposes the database size of the reachable etcd members,
+// dropping the ones of the removed, or unreachable, members.
+func observeEtcdMembers(dataStoreName string, status *kamajiv1alpha1.EtcdMaintenanceStatus) {
+	deleteEtcdMetrics(dataStoreName, false)
+
+	for _, member := range status.Members {
+		if len(member.MemberID) == 0 {
+			continue
+		}
+
+		etcdDBSize.WithLabelValues(dataStoreName, member.Endpoint).Set(float64(member.DBSize))
+		etcdDBSizeInUse.WithLabelValues(dataStoreName, member.Endpoint).Set(float64(member.DBSizeInUse))
+
+		ratio := 0.0
+		if member.DBSize > 0 {
+			ratio = float64(member.DBSize-member.DBSizeInUse) / float64(member.DBSize)
+		}
+
+		etcdFragmentation.WithLabelValues(dataStoreName, member.Endpoint).Set(ratio)
+	}
+}
+
+// deleteEtcdMetrics drops the etcd members metrics of the given DataStore,
+// including the defragmentations counter when the maintenance is not performed anymore.
+func deleteEtcdMetrics(dataStoreName string, defragmentations bool) {
+	labels := prometheus.Labels{"datastore": dataStoreName}
+
+	etcdDBSize.DeletePartialMatch(labels)
+	etcdDBSizeInUse.DeletePartialMatch(labels)
+	etcdFragmentation.DeletePartialMatch(labels)
+
+	if defragmentations {
+		etcdDefragmentations.DeletePartialMatch(labels)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+type fakeEtcd struct {
+	endpoints    []string
+	members      map[string]*datastore.EtcdMemberStatus
+	alarms       map[uint64][]string
+	unreachable  string
+	defragmented []string
+	compacted    int64
+}
+
+func (f *fakeEtcd) Endpoints() []string {
+	return f.endpoints
+}
+
+func (f *fakeEtcd) MemberStatus(_ context.Context, endpoint string) (*datastore.EtcdMemberStatus, error) {
+	if endpoint == f.unreachable {
+		return nil, fmt.Errorf("context deadline exceeded")
+	}
+
+	member := *f.members[endpoint]
+
+	return &member, nil
+}
+
+func (f *fakeEtcd) Alarms(context.Context) (map[uint64][]string, error) {
+	alarms := make(map[uint64][]string, len(f.alarms))
+	for id, i := range f.alarms {
+		alarms[id] = append([]string{}, i...)
+	}
+
+	return alarms, nil
+}
+
+func (f *fakeEtcd) Compact(_ context.Context, revision int64) error {
+	f.compacted = revision
+
+	return nil
+}
+
+func (f *fakeEtcd) Defragment(_ context.Context, endpoint string) error {
+	f.defragmented = append(f.defragmented, endpoint)
+	f.members[endpoint].DBSize = f.members[endpoint].DBSizeInUse
+
+	return nil
+}
+
+func (f *fakeEtcd) DisarmNoSpaceAlarm(_ context.Context, memberID uint64) error {
+	delete(f.alarms, memberID)
+
+	return nil
+}
+
+func TestMaintainEtcd(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	etcd := &fakeEtcd{
+		endpoints: []string{"etcd-0:2379", "etcd-1:2379", "etcd-2:2379"},
+		members: map[string]*datastore.EtcdMemberStatus{
+			"etcd-0:2379": {MemberID: 0xa, Revision: 42, DBSize: 300 << 20, DBSizeInUse: 100 << 20, IsLeader: true},
+			"etcd-1:2379": {MemberID: 0xb, Revision: 42, DBSize: 110 << 20, DBSizeInUse: 100 << 20},
+			"etcd-2:2379": {MemberID: 0xc, Revision: 42, DBSize: 250 << 20, DBSizeInUse: 100 << 20},
+		},
+		alarms: map[uint64][]string{},
+	}
+
+	dataStore := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}}
+	dataStore.Spec.Driver = kamajiv1alpha1.EtcdDriver
+	dataStore.Spec.EtcdMaintenance = &kamajiv1alpha1.EtcdMaintenanceSpec{
+		DefragmentationInterval:  metav1.Duration{Duration: 24 * time.Hour},
+		DefragmentationThreshold: resource.MustParse("100Mi"),
+		DisarmAlarms:             true,
+	}
+
+	maintain := func(requeueAfter time.Duration, defragmented ...string) {
+		etcd.defragmented = nil
+
+		result, err := maintainEtcd(ctx, dataStore, etcd, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if result.RequeueAfter != requeueAfter {
+			t.Fatalf("expected requeue after %s, got %s", requeueAfter, result.RequeueAfter)
+		}
+
+		if fmt.Sprint(etcd.defragmented) != fmt.Sprint(defragmented) {
+			t.Fatalf("expected %v to be defragmented, got %v", defragmented, etcd.defragmented)
+		}
+	}
+
+	etcd.unreachable = "etcd-1:2379"
+	// The members are not defragmented while the cluster is degraded.
+	maintain(etcdMaintenanceCheckInterval)
+
+	if member := dataStore.Status.Etcd.Members[1]; len(member.MemberID) > 0 || len(member.Message) == 0 {
+		t.Fatalf("expected the unreachable member to be reported, got %+v", member)
+	}
+
+	etcd.unreachable = ""
+	// The followers are defragmented before the leader, the members below the threshold are skipped.
+	maintain(etcdMaintenanceStepInterval, "etcd-2:2379")
+	// The next member is defragmented once the step interval is elapsed, regardless of the reconciliations.
+	now = now.Add(etcdMaintenanceStepInterval / 2)
+	maintain(etcdMaintenanceStepInterval / 2)
+
+	now = now.Add(etcdMaintenanceStepInterval / 2)
+	maintain(etcdMaintenanceStepInterval, "etcd-0:2379")
+
+	now = now.Add(etcdMaintenanceStepInterval)
+	maintain(etcdMaintenanceCheckInterval)
+
+	if member := dataStore.Status.Etcd.Members[0]; member.MemberID != "a" || member.DBSize != 100<<20 || member.Fragmentation != 0 || member.LastDefragmentation == nil {
+		t.Fatalf("unexpected defragmented member status %+v", member)
+	}
+
+	if member := dataStore.Status.Etcd.Members[1]; member.Fragmentation != 9 || member.LastDefragmentation != nil {
+		t.Fatalf("unexpected member status %+v", member)
+	}
+	// The defragmentations are performed again only once the interval is elapsed.
+	etcd.members["etcd-2:2379"].DBSize = 250 << 20
+	maintain(etcdMaintenanceCheckInterval)
+
+	now = now.Add(24 * time.Hour)
+	maintain(etcdMaintenanceStepInterval, "etcd-2:2379")
+	// The members raising a NOSPACE alarm are compacted, and defragmented, regardless of the threshold.
+	etcd.alarms[0xb] = []string{datastore.EtcdNoSpaceAlarm}
+	now = now.Add(etcdMaintenanceStepInterval)
+	maintain(etcdMaintenanceStepInterval, "etcd-1:2379")
+
+	if etcd.compacted != 42 || len(etcd.alarms) > 0 {
+		t.Fatalf("expected the keyspace to be compacted, and the alarm to be disarmed")
+	}
+
+	if member := dataStore.Status.Etcd.Members[1]; len(member.Alarms) > 0 {
+		t.Fatalf("unexpected alarms %v", member.Alarms)
+	}
+}
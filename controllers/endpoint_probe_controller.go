@@ -0,0 +1,252 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+var (
+	endpointProbeReachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_tenant_control_plane_endpoint_reachable",
+		Help: "Whether the advertised Tenant Control Plane endpoint is serving a healthy API Server with a trusted certificate (1) or not (0).",
+	}, []string{"namespace", "name"})
+	endpointProbeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kamaji_tenant_control_plane_endpoint_probe_duration_seconds",
+		Help:    "Duration of the probes against the advertised Tenant Control Plane endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(endpointProbeReachable, endpointProbeDuration)
+}
+
+// EndpointProbe periodically checks that the advertised Tenant Control Plane endpoint,
+// such as the LoadBalancer IP, the NodePort address, or the Ingress hostname, is serving the API Server
+// with a certificate signed by the Tenant Control Plane CA, and that its readiness endpoint is healthy.
+type EndpointProbe struct {
+	Client client.Client
+	// Interval is the period between two subsequent probes for the same Tenant Control Plane.
+	Interval time.Duration
+	// Timeout is the maximum duration of a single probe.
+	Timeout time.Duration
+}
+
+func (r *EndpointProbe) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			endpointProbeReachable.DeleteLabelValues(request.Namespace, request.Name)
+			endpointProbeDuration.DeleteLabelValues(request.Namespace, request.Name)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	condition := r.probe(ctx, tcp)
+	condition.ObservedGeneration = tcp.GetGeneration()
+
+	if condition.Status == metav1.ConditionTrue {
+		endpointProbeReachable.WithLabelValues(tcp.GetNamespace(), tcp.GetName()).Set(1)
+	} else {
+		endpointProbeReachable.WithLabelValues(tcp.GetNamespace(), tcp.GetName()).Set(0)
+	}
+
+	if err := r.updateCondition(ctx, request.NamespacedName, condition); err != nil {
+		logger.Error(err, "cannot update the endpoint reachability condition")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+func (r *EndpointProbe) probe(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) metav1.Condition {
+	condition := metav1.Condition{Type: kamajiv1alpha1.ConditionTypeEndpointReachable}
+	// The connections to the Tenant Control Planes scaled to zero are served by the activator:
+	// probing them would wake them up, preventing the scale to zero.
+	if tcp.ServedByActivator() {
+		condition.Status, condition.Reason = metav1.ConditionUnknown, kamajiv1alpha1.EndpointReachableReasonSleeping
+		condition.Message = "the Tenant Control Plane has been scaled to zero due to inactivity"
+
+		return condition
+	}
+
+	endpoint, err := r.advertisedEndpoint(tcp)
+	if err != nil || len(tcp.Status.Certificates.CA.SecretName) == 0 {
+		condition.Status, condition.Reason = metav1.ConditionUnknown, kamajiv1alpha1.EndpointReachableReasonNotAdvertised
+		condition.Message = "the Tenant Control Plane endpoint has not been advertised yet"
+
+		return condition
+	}
+
+	caSecret := &corev1.Secret{}
+	if err = r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.CA.SecretName}, caSecret); err != nil {
+		condition.Status, condition.Reason = metav1.ConditionUnknown, kamajiv1alpha1.EndpointReachableReasonCAUnavailable
+		condition.Message = fmt.Sprintf("cannot retrieve the Tenant Control Plane CA: %s", err.Error())
+
+		return condition
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caSecret.Data[kubeadmconstants.CACertName]) {
+		condition.Status, condition.Reason = metav1.ConditionUnknown, kamajiv1alpha1.EndpointReachableReasonCAUnavailable
+		condition.Message = "the Tenant Control Plane CA is not containing a valid certificate"
+
+		return condition
+	}
+
+	host, _, _ := net.SplitHostPort(endpoint)
+
+	httpClient := &http.Client{
+		Timeout: r.Timeout,
+		Transport: &http.Transport{
+			Proxy: nil,
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				ServerName: host,
+				MinVersion: tls.VersionTLS12,
+			},
+			DisableKeepAlives: true,
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/readyz", endpoint), nil)
+	if err != nil {
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, kamajiv1alpha1.EndpointReachableReasonUnreachable, err.Error()
+
+		return condition
+	}
+
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	endpointProbeDuration.WithLabelValues(tcp.GetNamespace(), tcp.GetName()).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err != nil && isCertificateVerificationError(err):
+		condition.Status, condition.Reason = metav1.ConditionFalse, kamajiv1alpha1.EndpointReachableReasonTLSVerificationError
+		condition.Message = fmt.Sprintf("the endpoint %s is not serving a certificate valid for the Tenant Control Plane: %s", endpoint, err.Error())
+	case err != nil:
+		condition.Status, condition.Reason = metav1.ConditionFalse, kamajiv1alpha1.EndpointReachableReasonUnreachable
+		condition.Message = fmt.Sprintf("the endpoint %s cannot be reached: %s", endpoint, err.Error())
+	default:
+		defer res.Body.Close()
+
+		_, _ = io.Copy(io.Discard, res.Body)
+
+		if res.StatusCode != http.StatusOK {
+			condition.Status, condition.Reason = metav1.ConditionFalse, kamajiv1alpha1.EndpointReachableReasonNotReady
+			condition.Message = fmt.Sprintf("the endpoint %s replied to the readiness check with status code %d", endpoint, res.StatusCode)
+
+			break
+		}
+
+		condition.Status, condition.Reason = metav1.ConditionTrue, kamajiv1alpha1.EndpointReachableReasonProbeSucceeded
+		condition.Message = fmt.Sprintf("the endpoint %s is serving a ready API Server", endpoint)
+	}
+
+	return condition
+}
+
+// advertisedEndpoint returns the endpoint announced to the Tenant Control Plane users:
+// when exposed using an Ingress, or the SNI proxy, the hostname is used rather than the Service address.
+func (r *EndpointProbe) advertisedEndpoint(tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	address, port, err := tcp.AssignedControlPlaneAddress()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case tcp.Spec.ControlPlane.SNIProxy != nil:
+		address, port = tcp.Spec.ControlPlane.SNIProxy.Hostname, tcp.Spec.ControlPlane.SNIProxy.Port
+	case tcp.Spec.ControlPlane.Ingress != nil && len(tcp.Spec.ControlPlane.Ingress.Hostname) > 0:
+		address, port = utilities.GetControlPlaneAddressAndPortFromHostname(tcp.Spec.ControlPlane.Ingress.Hostname, port)
+	}
+
+	return net.JoinHostPort(address, fmt.Sprintf("%d", port)), nil
+}
+
+func (r *EndpointProbe) updateCondition(ctx context.Context, namespacedName k8stypes.NamespacedName, condition metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		if err := r.Client.Get(ctx, namespacedName, tcp); err != nil {
+			return err
+		}
+		// Avoiding useless status updates, since they would trigger the reconciliation of the Tenant Control Plane.
+		if current := meta.FindStatusCondition(tcp.Status.Conditions, condition.Type); current != nil &&
+			current.Status == condition.Status &&
+			current.Reason == condition.Reason &&
+			current.Message == condition.Message &&
+			current.ObservedGeneration == condition.ObservedGeneration {
+			return nil
+		}
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, condition)
+
+		return r.Client.Status().Update(ctx, tcp)
+	})
+}
+
+func isCertificateVerificationError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
+
+func (r *EndpointProbe) SetupWithManager(mgr controllerruntime.Manager) error {
+	//nolint:forcetypeassert
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("endpointprobe").
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+				oldTCP, newTCP := updateEvent.ObjectOld.(*kamajiv1alpha1.TenantControlPlane), updateEvent.ObjectNew.(*kamajiv1alpha1.TenantControlPlane)
+
+				return oldTCP.Status.ControlPlaneEndpoint != newTCP.Status.ControlPlaneEndpoint ||
+					oldTCP.Status.Certificates.CA.Checksum != newTCP.Status.Certificates.CA.Checksum ||
+					oldTCP.Status.Certificates.APIServer.Checksum != newTCP.Status.Certificates.APIServer.Checksum ||
+					oldTCP.ServedByActivator() != newTCP.ServedByActivator() ||
+					oldTCP.GetGeneration() != newTCP.GetGeneration()
+			},
+		})).
+		Complete(r)
+}
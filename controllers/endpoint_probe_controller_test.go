@@ -0,0 +1,300 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return scheme
+}
+
+func TestIsCertificateVerificationError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "unknown authority", err: x509.UnknownAuthorityError{}, expected: true},
+		{name: "hostname mismatch", err: x509.HostnameError{Host: "foo"}, expected: true},
+		{name: "invalid certificate", err: x509.CertificateInvalidError{Reason: x509.Expired}, expected: true},
+		{name: "wrapped", err: errors.Wrap(x509.UnknownAuthorityError{}, "Get \"https://foo\""), expected: true},
+		{name: "connection error", err: fmt.Errorf("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := isCertificateVerificationError(tt.err); actual != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAdvertisedEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		tcp      func(tcp *kamajiv1alpha1.TenantControlPlane)
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:    "not exposed",
+			tcp:     func(tcp *kamajiv1alpha1.TenantControlPlane) { tcp.Status.ControlPlaneEndpoint = "" },
+			wantErr: true,
+		},
+		{
+			name:     "service",
+			tcp:      func(*kamajiv1alpha1.TenantControlPlane) {},
+			expected: "10.0.0.1:6443",
+		},
+		{
+			name: "ingress",
+			tcp: func(tcp *kamajiv1alpha1.TenantControlPlane) {
+				tcp.Spec.ControlPlane.Ingress = &kamajiv1alpha1.IngressSpec{Hostname: "tenant.example.com"}
+			},
+			expected: "tenant.example.com:6443",
+		},
+		{
+			name: "ingress with port",
+			tcp: func(tcp *kamajiv1alpha1.TenantControlPlane) {
+				tcp.Spec.ControlPlane.Ingress = &kamajiv1alpha1.IngressSpec{Hostname: "tenant.example.com:443"}
+			},
+			expected: "tenant.example.com:443",
+		},
+		{
+			name: "sni proxy",
+			tcp: func(tcp *kamajiv1alpha1.TenantControlPlane) {
+				tcp.Spec.NetworkProfile.Address = "192.168.1.1"
+				tcp.Spec.ControlPlane.SNIProxy = &kamajiv1alpha1.SNIProxySpec{Hostname: "tenant.example.com", Port: 443}
+			},
+			expected: "tenant.example.com:443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.NetworkProfile.Port = 6443
+			tcp.Status.ControlPlaneEndpoint = "10.0.0.1:6443"
+			tcp.Status.Kubernetes.Service.Address = "10.0.0.1"
+
+			tt.tcp(tcp)
+
+			endpoint, err := (&EndpointProbe{}).advertisedEndpoint(tcp)
+
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Fatalf("expected error, got %s", endpoint)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case endpoint != tt.expected:
+				t.Fatalf("expected %s, got %s", tt.expected, endpoint)
+			}
+		})
+	}
+}
+
+func TestEndpointProbeReconcile(t *testing.T) {
+	ready := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ready.Close()
+
+	notReady := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer notReady.Close()
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		caSecret  *corev1.Secret
+		sleeping  bool
+		reason    string
+		status    metav1.ConditionStatus
+		reachable float64
+	}{
+		{
+			name:      "trusted and ready",
+			endpoint:  ready.Listener.Addr().String(),
+			caSecret:  caSecret(ready.Certificate()),
+			reason:    kamajiv1alpha1.EndpointReachableReasonProbeSucceeded,
+			status:    metav1.ConditionTrue,
+			reachable: 1,
+		},
+		{
+			name:     "trusted and not ready",
+			endpoint: notReady.Listener.Addr().String(),
+			caSecret: caSecret(notReady.Certificate()),
+			reason:   kamajiv1alpha1.EndpointReachableReasonNotReady,
+			status:   metav1.ConditionFalse,
+		},
+		{
+			name:     "untrusted certificate",
+			endpoint: ready.Listener.Addr().String(),
+			caSecret: caSecret(selfSignedCertificate(t)),
+			reason:   kamajiv1alpha1.EndpointReachableReasonTLSVerificationError,
+			status:   metav1.ConditionFalse,
+		},
+		{
+			name:     "missing CA",
+			endpoint: ready.Listener.Addr().String(),
+			reason:   kamajiv1alpha1.EndpointReachableReasonCAUnavailable,
+			status:   metav1.ConditionUnknown,
+		},
+		{
+			name:     "invalid CA",
+			endpoint: ready.Listener.Addr().String(),
+			caSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-ca"},
+				Data:       map[string][]byte{kubeadmconstants.CACertName: []byte("invalid")},
+			},
+			reason: kamajiv1alpha1.EndpointReachableReasonCAUnavailable,
+			status: metav1.ConditionUnknown,
+		},
+		{
+			name:     "scaled to zero",
+			endpoint: "127.0.0.1:1",
+			caSecret: caSecret(ready.Certificate()),
+			sleeping: true,
+			reason:   kamajiv1alpha1.EndpointReachableReasonSleeping,
+			status:   metav1.ConditionUnknown,
+		},
+		{
+			name:     "unreachable",
+			endpoint: "127.0.0.1:1",
+			caSecret: caSecret(ready.Certificate()),
+			reason:   kamajiv1alpha1.EndpointReachableReasonUnreachable,
+			status:   metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+			tcp.Status.ControlPlaneEndpoint = tt.endpoint
+			tcp.Status.Certificates.CA.SecretName = "tenant-ca"
+
+			if tt.sleeping {
+				tcp.Spec.ControlPlane.IdlePolicy = &kamajiv1alpha1.IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}}
+				tcp.Status.Conditions = []metav1.Condition{{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached}}
+			}
+
+			objects := []client.Object{tcp}
+			if tt.caSecret != nil {
+				objects = append(objects, tt.caSecret)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(objects...).Build()
+			probe := &EndpointProbe{Client: c, Interval: time.Minute, Timeout: 5 * time.Second}
+
+			result, err := probe.Reconcile(context.Background(), reconcile.Request{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if result.RequeueAfter != time.Minute {
+				t.Fatalf("expected requeue after the interval, got %s", result.RequeueAfter)
+			}
+
+			if err = c.Get(context.Background(), k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}, tcp); err != nil {
+				t.Fatal(err)
+			}
+
+			condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeEndpointReachable)
+			if condition == nil {
+				t.Fatal("expected the condition to be set")
+			}
+
+			if condition.Reason != tt.reason || condition.Status != tt.status {
+				t.Fatalf("expected %s/%s, got %s/%s: %s", tt.status, tt.reason, condition.Status, condition.Reason, condition.Message)
+			}
+
+			if value := testutil.ToFloat64(endpointProbeReachable.WithLabelValues("default", "tenant")); value != tt.reachable {
+				t.Fatalf("expected reachable metric %f, got %f", tt.reachable, value)
+			}
+		})
+	}
+}
+
+func caSecret(certificate *x509.Certificate) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-ca"},
+		Data: map[string][]byte{
+			kubeadmconstants.CACertName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}),
+		},
+	}
+}
+
+// selfSignedCertificate returns a CA not related to the one used by the test TLS servers.
+func selfSignedCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certificate
+}
@@ -0,0 +1,338 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// apiServerRequestsMetric is the kube-apiserver counter used to detect the Tenant Control Plane activity.
+const apiServerRequestsMetric = "apiserver_request_total"
+
+type idleActivity struct {
+	requests     float64
+	lastActivity time.Time
+}
+
+// IdleTracker scales the Tenant Control Planes with an idle policy to zero when no API requests are received
+// for the configured duration: the activity is measured by scraping the kube-apiserver request metrics of each replica.
+// Once woken up by the activator, the Tenant Control Plane is marked as active when the Deployment is ready.
+type IdleTracker struct {
+	Client client.Client
+	// Interval is the period between two subsequent scrapes of the API Server metrics for the same Tenant Control Plane.
+	Interval time.Duration
+
+	mu       sync.Mutex
+	activity map[k8stypes.NamespacedName]idleActivity
+}
+
+func (r *IdleTracker) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.forget(request.NamespacedName)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		r.forget(request.NamespacedName)
+
+		return reconcile.Result{}, nil
+	}
+
+	policy, condition := tcp.Spec.ControlPlane.IdlePolicy, meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle)
+
+	switch {
+	case policy == nil:
+		// The idle policy has been removed: waking up the Tenant Control Plane, if required.
+		r.forget(request.NamespacedName)
+
+		if condition == nil {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, r.updateCondition(ctx, request.NamespacedName, nil)
+	case condition != nil && condition.Status == metav1.ConditionTrue:
+		// Scaled to zero: the activator is in charge of waking up the Tenant Control Plane.
+		r.forget(request.NamespacedName)
+
+		return reconcile.Result{}, nil
+	case condition != nil && condition.Reason == kamajiv1alpha1.IdleReasonWakingUp:
+		r.forget(request.NamespacedName)
+
+		if tcp.Status.Kubernetes.Deployment.ReadyReplicas == 0 {
+			return reconcile.Result{RequeueAfter: r.Interval}, nil
+		}
+
+		return reconcile.Result{RequeueAfter: r.Interval}, r.updateCondition(ctx, request.NamespacedName, &metav1.Condition{
+			Type:    kamajiv1alpha1.ConditionTypeIdle,
+			Status:  metav1.ConditionFalse,
+			Reason:  kamajiv1alpha1.IdleReasonActive,
+			Message: "the Tenant Control Plane has been woken up",
+		})
+	}
+
+	if tcp.Status.Kubernetes.Version.Status == nil || *tcp.Status.Kubernetes.Version.Status != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	requests, err := r.requests(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the API Server requests count")
+
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	lastActivity := r.track(request.NamespacedName, requests)
+
+	if idleFor := time.Since(lastActivity); idleFor < policy.IdleTimeout.Duration {
+		if condition == nil {
+			if err = r.updateCondition(ctx, request.NamespacedName, &metav1.Condition{
+				Type:    kamajiv1alpha1.ConditionTypeIdle,
+				Status:  metav1.ConditionFalse,
+				Reason:  kamajiv1alpha1.IdleReasonActive,
+				Message: "the Tenant Control Plane is serving API requests",
+			}); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
+		requeueAfter := r.Interval
+		if remaining := policy.IdleTimeout.Duration - idleFor; remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	logger.Info("scaling to zero the Tenant Control Plane due to inactivity", "lastActivity", lastActivity)
+
+	r.forget(request.NamespacedName)
+
+	return reconcile.Result{}, r.updateCondition(ctx, request.NamespacedName, &metav1.Condition{
+		Type:    kamajiv1alpha1.ConditionTypeIdle,
+		Status:  metav1.ConditionTrue,
+		Reason:  kamajiv1alpha1.IdleReasonInactivityTimeoutReached,
+		Message: fmt.Sprintf("no API requests received since %s", lastActivity.UTC().Format(time.RFC3339)),
+	})
+}
+
+// track stores the current API requests count, returning the last time it changed.
+func (r *IdleTracker) track(namespacedName k8stypes.NamespacedName, requests float64) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.activity == nil {
+		r.activity = map[k8stypes.NamespacedName]idleActivity{}
+	}
+
+	current, ok := r.activity[namespacedName]
+	if !ok || current.requests != requests {
+		current = idleActivity{requests: requests, lastActivity: time.Now()}
+
+		r.activity[namespacedName] = current
+	}
+
+	return current.lastActivity
+}
+
+func (r *IdleTracker) forget(namespacedName k8stypes.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.activity, namespacedName)
+}
+
+// requests returns the sum of the API requests served by each ready API Server replica:
+// the requests issued by the control plane components, the nodes, or Kamaji itself, are ignored.
+func (r *IdleTracker) requests(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (float64, error) {
+	restConfig, err := utilities.GetRESTClientConfig(ctx, r.Client, tcp)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve the Tenant Control Plane REST configuration")
+	}
+	// The requests must be collected from each replica, since the counters are not shared.
+	pods := &corev1.PodList{}
+	if err = r.Client.List(ctx, pods, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels{"kamaji.clastix.io/name": tcp.GetName()}); err != nil {
+		return 0, errors.Wrap(err, "cannot list the Tenant Control Plane pods")
+	}
+
+	var total float64
+
+	for _, pod := range pods.Items {
+		if len(pod.Status.PodIP) == 0 || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		podConfig := rest.CopyConfig(restConfig)
+		podConfig.Host = fmt.Sprintf("https://%s", net.JoinHostPort(pod.Status.PodIP, fmt.Sprintf("%d", tcp.Spec.NetworkProfile.Port)))
+		podConfig.TLSClientConfig.ServerName = fmt.Sprintf("%s.%s.svc.cluster.local", tcp.GetName(), tcp.GetNamespace())
+
+		requests, scrapeErr := r.scrape(ctx, podConfig)
+		if scrapeErr != nil {
+			return 0, errors.Wrap(scrapeErr, fmt.Sprintf("cannot scrape the metrics of the pod %s", pod.GetName()))
+		}
+
+		total += requests
+	}
+
+	return total, nil
+}
+
+func (r *IdleTracker) scrape(ctx context.Context, config *rest.Config) (float64, error) {
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/metrics", config.Host), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return countActivityRequests(res.Body)
+}
+
+// countActivityRequests returns the sum of the API requests counters parsed from the given metrics exposition,
+// considering only the ones matching isActivityRequest.
+func countActivityRequests(reader io.Reader) (float64, error) {
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	family, ok := families[apiServerRequestsMetric]
+	if !ok {
+		return 0, nil
+	}
+
+	var total float64
+
+	for _, metric := range family.GetMetric() {
+		labels := make(map[string]string, len(metric.GetLabel()))
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		if !isActivityRequest(labels) {
+			continue
+		}
+
+		total += metric.GetCounter().GetValue()
+	}
+
+	return total, nil
+}
+
+// isActivityRequest returns true when the request, identified by the apiserver_request_total labels, is considered
+// as activity. The metric is not reporting the requesting user, thus the requests issued by the control plane
+// components, the kubelets, and the Kamaji controllers, are recognized by the verb, resource, and subresource:
+//   - read-only requests are ignored, since they're continuously issued by the informers, and upon each reconciliation
+//     of the Kamaji controllers, with the exception of the container logs;
+//   - non resource URLs, such as the health checks, the discovery, and the metrics scraped by Kamaji itself;
+//   - leases, renewed by the leader election of the control plane components, and by the kubelets heartbeat;
+//   - events, emitted by any component;
+//   - status subresources, updated by the controllers and the kubelets to report the observed state;
+//   - service account tokens, token reviews, and subject access reviews, issued by the kubelets, and the Konnectivity server.
+func isActivityRequest(labels map[string]string) bool {
+	switch {
+	case labels["resource"] == "":
+		return false
+	case labels["resource"] == "leases", labels["resource"] == "events":
+		return false
+	case labels["subresource"] == "status", labels["subresource"] == "token":
+		return false
+	case labels["resource"] == "tokenreviews", labels["resource"] == "subjectaccessreviews":
+		return false
+	}
+
+	switch labels["verb"] {
+	case "POST", "PUT", "PATCH", "APPLY", "DELETE", "DELETECOLLECTION", "CONNECT":
+		return true
+	case "GET":
+		return labels["resource"] == "pods" && labels["subresource"] == "log"
+	default:
+		return false
+	}
+}
+
+// updateCondition sets the provided idle condition, or removes it when nil.
+func (r *IdleTracker) updateCondition(ctx context.Context, namespacedName k8stypes.NamespacedName, condition *metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		if err := r.Client.Get(ctx, namespacedName, tcp); err != nil {
+			return err
+		}
+
+		if condition == nil {
+			meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle)
+		} else {
+			condition.ObservedGeneration = tcp.GetGeneration()
+
+			meta.SetStatusCondition(&tcp.Status.Conditions, *condition)
+		}
+
+		return r.Client.Status().Update(ctx, tcp)
+	})
+}
+
+func (r *IdleTracker) SetupWithManager(mgr controllerruntime.Manager) error {
+	//nolint:forcetypeassert
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("idletracker").
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+				oldTCP, newTCP := updateEvent.ObjectOld.(*kamajiv1alpha1.TenantControlPlane), updateEvent.ObjectNew.(*kamajiv1alpha1.TenantControlPlane)
+				// The activity is tracked by the periodic requeue: only the changes to the idle policy,
+				// to the idle condition, and to the readiness of the Tenant Control Plane, must be reconciled.
+				return oldTCP.GetGeneration() != newTCP.GetGeneration() ||
+					!reflect.DeepEqual(meta.FindStatusCondition(oldTCP.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle), meta.FindStatusCondition(newTCP.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle)) ||
+					oldTCP.Status.Kubernetes.Deployment.ReadyReplicas != newTCP.Status.Kubernetes.Deployment.ReadyReplicas ||
+					!reflect.DeepEqual(oldTCP.Status.Kubernetes.Version.Status, newTCP.Status.Kubernetes.Version.Status)
+			},
+		})).
+		Complete(r)
+}
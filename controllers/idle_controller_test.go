@@ -0,0 +1,189 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestIsActivityRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected bool
+	}{
+		{name: "user creating a deployment", labels: map[string]string{"verb": "POST", "group": "apps", "resource": "deployments"}, expected: true},
+		{name: "user applying a configmap", labels: map[string]string{"verb": "APPLY", "resource": "configmaps"}, expected: true},
+		{name: "user deleting a pod", labels: map[string]string{"verb": "DELETE", "resource": "pods"}, expected: true},
+		{name: "user executing in a pod", labels: map[string]string{"verb": "CONNECT", "resource": "pods", "subresource": "exec"}, expected: true},
+		{name: "user reading container logs", labels: map[string]string{"verb": "GET", "resource": "pods", "subresource": "log"}, expected: true},
+		{name: "informer watch", labels: map[string]string{"verb": "WATCH", "resource": "pods"}},
+		{name: "informer list", labels: map[string]string{"verb": "LIST", "resource": "pods"}},
+		{name: "Kamaji addon reconciliation read", labels: map[string]string{"verb": "GET", "group": "apps", "resource": "deployments"}},
+		{name: "health check", labels: map[string]string{"verb": "GET", "resource": ""}},
+		{name: "controller manager leader election", labels: map[string]string{"verb": "PUT", "group": "coordination.k8s.io", "resource": "leases"}},
+		{name: "event", labels: map[string]string{"verb": "POST", "resource": "events"}},
+		{name: "kubelet node status", labels: map[string]string{"verb": "PATCH", "resource": "nodes", "subresource": "status"}},
+		{name: "controller deployment status", labels: map[string]string{"verb": "PUT", "group": "apps", "resource": "deployments", "subresource": "status"}},
+		{name: "kubelet service account token", labels: map[string]string{"verb": "POST", "resource": "serviceaccounts", "subresource": "token"}},
+		{name: "token review", labels: map[string]string{"verb": "POST", "group": "authentication.k8s.io", "resource": "tokenreviews"}},
+		{name: "subject access review", labels: map[string]string{"verb": "POST", "group": "authorization.k8s.io", "resource": "subjectaccessreviews"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := isActivityRequest(tt.labels); actual != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCountActivityRequests(t *testing.T) {
+	metrics := `# HELP apiserver_request_total [STABLE] Counter of apiserver requests broken out for each verb, dry run value, group, version, resource, scope, component, and HTTP response code.
+# TYPE apiserver_request_total counter
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="",scope="",subresource="/readyz",verb="GET",version=""} 120
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="coordination.k8s.io",resource="leases",scope="resource",subresource="",verb="PUT",version="v1"} 340
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="configmaps",scope="resource",subresource="",verb="GET",version="v1"} 55
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="pods",scope="namespace",subresource="",verb="WATCH",version="v1"} 12
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="nodes",scope="resource",subresource="status",verb="PATCH",version="v1"} 30
+apiserver_request_total{code="201",component="apiserver",dry_run="",group="apps",resource="deployments",scope="resource",subresource="",verb="POST",version="v1"} 3
+apiserver_request_total{code="200",component="apiserver",dry_run="",group="",resource="pods",scope="resource",subresource="log",verb="GET",version="v1"} 2
+# HELP apiserver_current_inflight_requests [STABLE] Maximal number of currently used inflight request limit of this apiserver per request kind in last second.
+# TYPE apiserver_current_inflight_requests gauge
+apiserver_current_inflight_requests{request_kind="mutating"} 1
+`
+
+	total, err := countActivityRequests(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if total != 5 {
+		t.Fatalf("expected 5 activity requests, got %f", total)
+	}
+
+	if total, err = countActivityRequests(strings.NewReader("")); err != nil || total != 0 {
+		t.Fatalf("expected no requests with a missing metric, got %f, %v", total, err)
+	}
+}
+
+func TestIdleTrackerTrack(t *testing.T) {
+	tracker := &IdleTracker{}
+	tenant00, tenant01 := k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"}, k8stypes.NamespacedName{Namespace: "default", Name: "tenant-01"}
+
+	first := tracker.track(tenant00, 10)
+	if time.Since(first) > time.Second {
+		t.Fatalf("expected the first observation to be considered as activity, got %s", first)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if unchanged := tracker.track(tenant00, 10); !unchanged.Equal(first) {
+		t.Fatalf("expected the last activity to be retained with unchanged requests, got %s instead of %s", unchanged, first)
+	}
+
+	changed := tracker.track(tenant00, 11)
+	if !changed.After(first) {
+		t.Fatalf("expected the last activity to be updated with new requests, got %s", changed)
+	}
+	// A decreasing counter, such as upon an API Server restart, is considered as activity too.
+	if restarted := tracker.track(tenant00, 0); !restarted.After(first) {
+		t.Fatalf("expected the last activity to be updated upon a counter reset, got %s", restarted)
+	}
+
+	if other := tracker.track(tenant01, 0); other.Before(changed) {
+		t.Fatalf("expected the Tenant Control Planes to be tracked separately, got %s", other)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	tracker.forget(tenant00)
+
+	if forgotten := tracker.track(tenant00, 0); !forgotten.After(changed) {
+		t.Fatalf("expected the tracking to start again once forgotten, got %s", forgotten)
+	}
+}
+
+func TestIdleTrackerReconcile(t *testing.T) {
+	idlePolicy := &kamajiv1alpha1.IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name          string
+		idlePolicy    *kamajiv1alpha1.IdlePolicySpec
+		condition     *metav1.Condition
+		readyReplicas int32
+		expected      *metav1.Condition
+	}{
+		{
+			name:      "idle policy removed",
+			condition: &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached},
+		},
+		{
+			name:       "scaled to zero",
+			idlePolicy: idlePolicy,
+			condition:  &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached},
+			expected:   &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached},
+		},
+		{
+			name:       "waking up",
+			idlePolicy: idlePolicy,
+			condition:  &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: kamajiv1alpha1.IdleReasonWakingUp},
+			expected:   &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: kamajiv1alpha1.IdleReasonWakingUp},
+		},
+		{
+			name:          "woken up",
+			idlePolicy:    idlePolicy,
+			condition:     &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: kamajiv1alpha1.IdleReasonWakingUp},
+			readyReplicas: 1,
+			expected:      &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: kamajiv1alpha1.IdleReasonActive},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+			tcp.Spec.ControlPlane.IdlePolicy = tt.idlePolicy
+			tcp.Status.Kubernetes.Deployment.ReadyReplicas = tt.readyReplicas
+
+			if tt.condition != nil {
+				meta.SetStatusCondition(&tcp.Status.Conditions, *tt.condition)
+			}
+
+			c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(tcp).Build()
+
+			if _, err := (&IdleTracker{Client: c, Interval: time.Minute}).Reconcile(context.Background(), reconcile.Request{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if err := c.Get(context.Background(), k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}, tcp); err != nil {
+				t.Fatal(err)
+			}
+
+			condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle)
+
+			switch {
+			case tt.expected == nil && condition != nil:
+				t.Fatalf("expected no idle condition, got %+v", condition)
+			case tt.expected == nil:
+				return
+			case condition == nil:
+				t.Fatal("expected the idle condition to be set")
+			case condition.Status != tt.expected.Status || condition.Reason != tt.expected.Reason:
+				t.Fatalf("expected %s/%s, got %s/%s", tt.expected.Status, tt.expected.Reason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
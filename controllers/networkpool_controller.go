@@ -0,0 +1,161 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/networkpool"
+)
+
+// NetworkPool releases the addresses, and the ports, allocated to the Tenant Control Planes which have been deleted,
+// or which are not using them anymore. The allocations are performed by the defaulting webhook before the
+// Tenant Control Plane is persisted: the ones of the Tenant Control Planes never created, e.g. due to a rejection
+// by a validating webhook, are released once the grace period is expired.
+type NetworkPool struct {
+	Client      client.Client
+	GracePeriod time.Duration
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=networkpools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=networkpools/status,verbs=get;update;patch
+
+func (r *NetworkPool) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var requeueAfter time.Duration
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		requeueAfter = 0
+
+		pool := &kamajiv1alpha1.NetworkPool{}
+		if err := r.Client.Get(ctx, request.NamespacedName, pool); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		allocations := make([]kamajiv1alpha1.NetworkPoolAllocation, 0, len(pool.Status.Allocations))
+
+		for _, allocation := range pool.Status.Allocations {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+
+			err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: allocation.Namespace, Name: allocation.Name}, tcp)
+
+			switch {
+			case k8serrors.IsNotFound(err):
+				if remaining := r.GracePeriod - time.Since(allocation.AllocatedAt.Time); remaining > 0 {
+					allocations = append(allocations, allocation)
+
+					if requeueAfter == 0 || remaining < requeueAfter {
+						requeueAfter = remaining
+					}
+
+					continue
+				}
+
+				logger.Info("releasing the network allocation of a deleted Tenant Control Plane", "tenantControlPlane", allocation.Namespace+"/"+allocation.Name)
+			case err != nil:
+				return err
+			default:
+				if released, ok := releaseUnused(allocation, tcp); ok {
+					allocations = append(allocations, released)
+				}
+			}
+		}
+
+		if equalAllocations(pool.Status.Allocations, allocations) {
+			return nil
+		}
+
+		pool.Status.Allocations = allocations
+
+		return r.Client.Status().Update(ctx, pool)
+	})
+	if err != nil {
+		logger.Error(err, "cannot release the network allocations")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// releaseUnused drops from the allocation the address, and the ports, not used anymore by the Tenant Control Plane,
+// returning false when nothing is left.
+func releaseUnused(allocation kamajiv1alpha1.NetworkPoolAllocation, tcp *kamajiv1alpha1.TenantControlPlane) (kamajiv1alpha1.NetworkPoolAllocation, bool) {
+	released := *allocation.DeepCopy()
+
+	if released.Address != tcp.Spec.NetworkProfile.Address {
+		released.Address = ""
+	}
+
+	inUse := networkpool.TenantControlPlanePorts(tcp)
+
+	released.Ports = nil
+
+	for _, port := range allocation.Ports {
+		if inUse.Has(port) {
+			released.Ports = append(released.Ports, port)
+		}
+	}
+
+	return released, len(released.Address) > 0 || len(released.Ports) > 0
+}
+
+func equalAllocations(a, b []kamajiv1alpha1.NetworkPoolAllocation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Namespace != b[i].Namespace || a[i].Name != b[i].Name || a[i].Address != b[i].Address || len(a[i].Ports) != len(b[i].Ports) {
+			return false
+		}
+
+		for j := range a[i].Ports {
+			if a[i].Ports[j] != b[i].Ports[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (r *NetworkPool) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.NetworkPool{}).
+		Watches(&source.Kind{Type: &kamajiv1alpha1.TenantControlPlane{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			pools := &kamajiv1alpha1.NetworkPoolList{}
+			if err := r.Client.List(context.Background(), pools); err != nil {
+				return nil
+			}
+
+			requests := make([]reconcile.Request, 0, len(pools.Items))
+
+			for _, pool := range pools.Items {
+				for _, allocation := range pool.Status.Allocations {
+					if allocation.Namespace == object.GetNamespace() && allocation.Name == object.GetName() {
+						requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: pool.GetName()}})
+
+						break
+					}
+				}
+			}
+
+			return requests
+		})).
+		Complete(r)
+}
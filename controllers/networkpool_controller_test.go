@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestNetworkPoolRelease(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "running"}}
+	tcp.Spec.NetworkProfile.Address = "10.0.0.1"
+	tcp.Spec.NetworkProfile.Port = 31000
+	tcp.Spec.NetworkProfile.NodePort = 31004
+
+	expired := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	pool := &kamajiv1alpha1.NetworkPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+		Status: kamajiv1alpha1.NetworkPoolStatus{
+			Allocations: []kamajiv1alpha1.NetworkPoolAllocation{
+				// The Konnectivity addon has been disabled: its port must be released.
+				{Namespace: "default", Name: "running", Address: "10.0.0.1", Ports: []int32{31000, 31001, 31004}, AllocatedAt: expired},
+				{Namespace: "default", Name: "deleted", Address: "10.0.0.2", Ports: []int32{31002}, AllocatedAt: expired},
+				{Namespace: "default", Name: "pending", Address: "10.0.0.3", Ports: []int32{31003}, AllocatedAt: metav1.Now()},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp, pool).Build()
+
+	result, err := (&NetworkPool{Client: c, GracePeriod: time.Minute}).Reconcile(context.Background(), reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "pool"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Minute {
+		t.Fatalf("expected a requeue within the grace period, got %s", result.RequeueAfter)
+	}
+
+	if err = c.Get(context.Background(), k8stypes.NamespacedName{Name: "pool"}, pool); err != nil {
+		t.Fatal(err)
+	}
+
+	allocations := pool.Status.Allocations
+	if len(allocations) != 2 {
+		t.Fatalf("expected 2 allocations, got %+v", allocations)
+	}
+
+	if allocations[0].Name != "running" || allocations[0].Address != "10.0.0.1" || len(allocations[0].Ports) != 2 || allocations[0].Ports[0] != 31000 || allocations[0].Ports[1] != 31004 {
+		t.Fatalf("expected the unused port to be released, got %+v", allocations[0])
+	}
+
+	if allocations[1].Name != "pending" {
+		t.Fatalf("expected the allocation within the grace period to be retained, got %+v", allocations[1])
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// RateLimiterConfig is the configuration of the rate limiter applied by a controller upon the requeue of the requests,
+// such as the failed reconciliations: the slowest between the per-item exponential backoff, and the overall bucket, wins.
+type RateLimiterConfig struct {
+	// BaseDelay and MaxDelay are the bounds of the per-item exponential backoff.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// QPS and Burst are the overall token bucket settings.
+	QPS   float64
+	Burst int
+}
+
+// DefaultRateLimiterConfig matches the controller-runtime default rate limiter.
+var DefaultRateLimiterConfig = RateLimiterConfig{
+	BaseDelay: 5 * time.Millisecond,
+	MaxDelay:  1000 * time.Second,
+	QPS:       10,
+	Burst:     100,
+}
+
+// RateLimiter returns the rate limiter for the given configuration,
+// the unset values fall back to the default ones.
+func (in RateLimiterConfig) RateLimiter() ratelimiter.RateLimiter {
+	if in.BaseDelay <= 0 {
+		in.BaseDelay = DefaultRateLimiterConfig.BaseDelay
+	}
+
+	if in.MaxDelay <= 0 {
+		in.MaxDelay = DefaultRateLimiterConfig.MaxDelay
+	}
+
+	if in.QPS <= 0 {
+		in.QPS = DefaultRateLimiterConfig.QPS
+	}
+
+	if in.Burst <= 0 {
+		in.Burst = DefaultRateLimiterConfig.Burst
+	}
+
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(in.BaseDelay, in.MaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(in.QPS), in.Burst)},
+	)
+}
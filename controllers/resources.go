@@ -14,8 +14,11 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/finalizers"
+	"github.com/clastix/kamaji/internal/activator"
 	builder "github.com/clastix/kamaji/internal/builders/controlplane"
 	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/resources"
 	ds "github.com/clastix/kamaji/internal/resources/datastore"
 	"github.com/clastix/kamaji/internal/resources/konnectivity"
@@ -32,6 +35,7 @@ type GroupResourceBuilderConfiguration struct {
 	KamajiServiceAccount string
 	KamajiService        string
 	KamajiMigrateImage   string
+	Activator            *activator.Activator
 }
 
 type GroupDeletableResourceBuilderConfiguration struct {
@@ -56,6 +60,13 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 	var res []resources.DeletableResource
 
 	if controllerutil.ContainsFinalizer(tcp, finalizers.DatastoreFinalizer) {
+		if config.tcpReconcilerConfig.GitOps.Enabled() {
+			res = append(res, &resources.GitOpsRegistration{
+				Client:       config.client,
+				Registration: config.tcpReconcilerConfig.GitOps,
+			})
+		}
+
 		res = append(res, &ds.Setup{
 			Client:     config.client,
 			Connection: config.connection,
@@ -66,18 +77,20 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 }
 
 func getDefaultResources(config GroupResourceBuilderConfiguration) []resources.Resource {
-	resources := getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService)
+	resources := getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService, config.tcpReconcilerConfig.Images)
 	resources = append(resources, getUpgradeResources(config.client)...)
 	resources = append(resources, getKubernetesServiceResources(config.client)...)
+	resources = append(resources, getActivatorResources(config.client, config.Activator)...)
 	resources = append(resources, getKubeadmConfigResources(config.client, getTmpDirectory(config.tcpReconcilerConfig.TmpBaseDirectory, config.tenantControlPlane), config.DataStore)...)
 	resources = append(resources, getKubernetesCertificatesResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
 	resources = append(resources, getKubeconfigResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
 	resources = append(resources, getKubernetesStorageResources(config.client, config.Connection, config.DataStore)...)
 	resources = append(resources, getKonnectivityServerRequirementsResources(config.client)...)
 	resources = append(resources, getKubernetesDeploymentResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
-	resources = append(resources, getKonnectivityServerPatchResources(config.client)...)
+	resources = append(resources, getKonnectivityServerPatchResources(config.client, config.tcpReconcilerConfig.Images)...)
 	resources = append(resources, getDataStoreMigratingCleanup(config.client, config.KamajiNamespace)...)
 	resources = append(resources, getKubernetesIngressResources(config.client)...)
+	resources = append(resources, getGitOpsResources(config.client, config.tcpReconcilerConfig.GitOps)...)
 
 	return resources
 }
@@ -92,11 +105,12 @@ func getDataStoreMigratingCleanup(c client.Client, kamajiNamespace string) []res
 	}
 }
 
-func getDataStoreMigratingResources(c client.Client, kamajiNamespace, migrateImage string, kamajiServiceAccount, kamajiService string) []resources.Resource {
+func getDataStoreMigratingResources(c client.Client, kamajiNamespace, migrateImage string, kamajiServiceAccount, kamajiService string, policy images.Policy) []resources.Resource {
 	return []resources.Resource{
 		&ds.Migrate{
 			Client:               c,
 			MigrateImage:         migrateImage,
+			Images:               policy,
 			KamajiNamespace:      kamajiNamespace,
 			KamajiServiceAccount: kamajiServiceAccount,
 			KamajiServiceName:    kamajiService,
@@ -120,6 +134,19 @@ func getKubernetesServiceResources(c client.Client) []resources.Resource {
 	}
 }
 
+func getActivatorResources(c client.Client, tcpActivator *activator.Activator) []resources.Resource {
+	if tcpActivator == nil {
+		return nil
+	}
+
+	return []resources.Resource{
+		&resources.KubernetesActivatorResource{
+			Client:    c,
+			Activator: tcpActivator,
+		},
+	}
+}
+
 func getKubeadmConfigResources(c client.Client, tmpDirectory string, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
 	var endpoints []string
 
@@ -216,6 +243,8 @@ func getKubernetesDeploymentResources(c client.Client, tcpReconcilerConfig Tenan
 			Client:             c,
 			DataStore:          dataStore,
 			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
+			Scheduling:         tcpReconcilerConfig.Scheduling,
+			Images:             tcpReconcilerConfig.Images,
 		},
 	}
 }
@@ -228,9 +257,18 @@ func getKubernetesIngressResources(c client.Client) []resources.Resource {
 	}
 }
 
-func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
+func getGitOpsResources(c client.Client, registration gitops.Registration) []resources.Resource {
+	return []resources.Resource{
+		&resources.GitOpsStaleRegistration{
+			Client:       c,
+			Registration: registration,
+		},
+	}
+}
+
+func GetExternalKonnectivityResources(c client.Client, policy images.Policy) []resources.Resource {
 	return []resources.Resource{
-		&konnectivity.Agent{Client: c},
+		&konnectivity.Agent{Client: c, Images: policy},
 		&konnectivity.ServiceAccountResource{Client: c},
 		&konnectivity.ClusterRoleBindingResource{Client: c},
 	}
@@ -244,9 +282,9 @@ func getKonnectivityServerRequirementsResources(c client.Client) []resources.Res
 	}
 }
 
-func getKonnectivityServerPatchResources(c client.Client) []resources.Resource {
+func getKonnectivityServerPatchResources(c client.Client, policy images.Policy) []resources.Resource {
 	return []resources.Resource{
-		&konnectivity.KubernetesDeploymentResource{Builder: builder.Konnectivity{Scheme: *c.Scheme()}, Client: c},
+		&konnectivity.KubernetesDeploymentResource{Builder: builder.Konnectivity{Scheme: *c.Scheme(), Images: policy}, Client: c},
 		&konnectivity.ServiceResource{Client: c},
 	}
 }
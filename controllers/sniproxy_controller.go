@@ -0,0 +1,73 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/sniproxy"
+)
+
+// SNIProxyRoutes keeps the SNI proxy routes in sync with the Tenant Control Plane specifications:
+// the API Server and the Konnectivity server are routed to the Tenant Control Plane Service.
+type SNIProxyRoutes struct {
+	Client client.Client
+	Router *sniproxy.Router
+}
+
+func (r *SNIProxyRoutes) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.Router.Delete(request.NamespacedName)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	sniProxy := tcp.Spec.ControlPlane.SNIProxy
+
+	if tcp.GetDeletionTimestamp() != nil || sniProxy == nil {
+		r.Router.Delete(request.NamespacedName)
+
+		return reconcile.Result{}, nil
+	}
+
+	routes := map[string]string{
+		sniProxy.Hostname: r.backend(tcp, tcp.Spec.NetworkProfile.GetServicePort()),
+	}
+
+	if konnectivity := tcp.Spec.Addons.Konnectivity; konnectivity != nil {
+		routes[sniProxy.KonnectivityHostname()] = r.backend(tcp, konnectivity.KonnectivityServerSpec.Port)
+	}
+
+	r.Router.Set(request.NamespacedName, routes)
+
+	logger.V(1).Info("SNI proxy routes updated", "routes", routes)
+
+	return reconcile.Result{}, nil
+}
+
+func (r *SNIProxyRoutes) backend(tcp *kamajiv1alpha1.TenantControlPlane, port int32) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", tcp.GetName(), tcp.GetNamespace(), port)
+}
+
+func (r *SNIProxyRoutes) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("sniproxy").
+		For(&kamajiv1alpha1.TenantControlPlane{}).
+		Complete(r)
+}
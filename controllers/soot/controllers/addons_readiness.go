@@ -0,0 +1,162 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+)
+
+// AddonsReadiness reports in the Tenant Control Plane status the rollout status of the addons workloads
+// in the Tenant Cluster, along with the AddonsReady condition gating the Tenant Control Plane readiness.
+type AddonsReadiness struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	client client.Client
+	logger logr.Logger
+}
+
+func (a *AddonsReadiness) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := a.GetTenantControlPlaneFunc()
+	if err != nil {
+		a.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	var rollout kamajiv1alpha1.AddonsRolloutStatus
+
+	if tcp.Spec.Addons.CoreDNS != nil {
+		if rollout.CoreDNS, err = a.deploymentRollout(ctx, kubeadm.CoreDNSName); err != nil {
+			a.logger.Error(err, "cannot retrieve the Deployment", "name", kubeadm.CoreDNSName)
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if tcp.Spec.Addons.KubeProxy != nil {
+		if rollout.KubeProxy, err = a.daemonSetRollout(ctx, kubeadm.KubeProxyName); err != nil {
+			a.logger.Error(err, "cannot retrieve the DaemonSet", "name", kubeadm.KubeProxyName)
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if tcp.Spec.Addons.Konnectivity != nil {
+		if rollout.Konnectivity, err = a.daemonSetRollout(ctx, konnectivity.AgentName); err != nil {
+			a.logger.Error(err, "cannot retrieve the DaemonSet", "name", konnectivity.AgentName)
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp, err = a.GetTenantControlPlaneFunc()
+		if err != nil {
+			return err
+		}
+
+		condition := addons.ReadinessCondition(tcp, rollout)
+		// Avoiding useless status updates, since they would trigger the reconciliation of the Tenant Control Plane.
+		if current := meta.FindStatusCondition(tcp.Status.Conditions, condition.Type); current != nil &&
+			current.Status == condition.Status &&
+			current.Reason == condition.Reason &&
+			current.Message == condition.Message &&
+			current.ObservedGeneration == condition.ObservedGeneration &&
+			equality.Semantic.DeepEqual(tcp.Status.Addons.Rollout, rollout) {
+			return nil
+		}
+
+		tcp.Status.Addons.Rollout = rollout
+		meta.SetStatusCondition(&tcp.Status.Conditions, condition)
+
+		return a.AdminClient.Status().Update(ctx, tcp)
+	}); err != nil {
+		a.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (a *AddonsReadiness) deploymentRollout(ctx context.Context, name string) (*kamajiv1alpha1.AddonRolloutStatus, error) {
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &kamajiv1alpha1.AddonRolloutStatus{Message: "the Deployment has not been yet created"}, nil
+		}
+
+		return nil, err
+	}
+
+	return addons.DeploymentRollout(deployment), nil
+}
+
+func (a *AddonsReadiness) daemonSetRollout(ctx context.Context, name string) (*kamajiv1alpha1.AddonRolloutStatus, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := a.client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, daemonSet); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &kamajiv1alpha1.AddonRolloutStatus{Message: "the DaemonSet has not been yet created"}, nil
+		}
+
+		return nil, err
+	}
+
+	return addons.DaemonSetRollout(daemonSet), nil
+}
+
+func (a *AddonsReadiness) SetupWithManager(mgr manager.Manager) error {
+	a.client = mgr.GetClient()
+	a.logger = mgr.GetLogger().WithName("addons_readiness")
+	a.TriggerChannel = make(chan event.GenericEvent)
+
+	isAddonWorkload := func(names ...string) predicate.Predicate {
+		return predicate.NewPredicateFuncs(func(object client.Object) bool {
+			if object.GetNamespace() != metav1.NamespaceSystem {
+				return false
+			}
+
+			for _, name := range names {
+				if object.GetName() == name {
+					return true
+				}
+			}
+
+			return false
+		})
+	}
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("addons_readiness").
+		For(&appsv1.Deployment{}, builder.WithPredicates(isAddonWorkload(kubeadm.CoreDNSName))).
+		Watches(&source.Kind{Type: &appsv1.DaemonSet{}}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(isAddonWorkload(kubeadm.KubeProxyName, konnectivity.AgentName))).
+		Watches(&source.Channel{Source: a.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(a)
+}
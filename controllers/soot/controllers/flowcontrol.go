@@ -0,0 +1,85 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	flowcontrolv1beta3 "k8s.io/api/flowcontrol/v1beta3"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+type FlowControl struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger logr.Logger
+}
+
+func (f *FlowControl) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := f.GetTenantControlPlaneFunc()
+	if err != nil {
+		f.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	f.logger.Info("start processing")
+
+	resource := &addons.FlowControl{Client: f.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		f.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		f.logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, f.AdminClient, tcp, resource); err != nil {
+		f.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	f.logger.Info("reconciliation processed")
+
+	return reconcile.Result{}, nil
+}
+
+func (f *FlowControl) SetupWithManager(mgr manager.Manager) error {
+	f.logger = mgr.GetLogger().WithName("flow_control")
+	f.TriggerChannel = make(chan event.GenericEvent)
+	// Drift correction: any change to the resources managed by Kamaji is enqueued back.
+	managed := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[constants.ControlPlaneLabelResource] == addons.FlowControlComponent
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&flowcontrolv1beta3.PriorityLevelConfiguration{}, builder.WithPredicates(managed)).
+		Watches(&source.Kind{Type: &flowcontrolv1beta3.FlowSchema{}}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(managed)).
+		Watches(&source.Channel{Source: f.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(f)
+}
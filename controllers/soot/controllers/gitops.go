@@ -0,0 +1,229 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	gitOpsComponent     = gitops.Component
+	gitOpsKubeconfigKey = "kubeconfig"
+)
+
+// GitOps registers the Tenant Cluster in the GitOps tools, using a dedicated identity bound to the configured ClusterRole:
+// its client certificate is issued again upon the rotation of the Tenant Control Plane CA, or when near to expiration.
+type GitOps struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	Registration              gitops.Registration
+
+	client client.Client
+	logger logr.Logger
+}
+
+func (g *GitOps) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := g.GetTenantControlPlaneFunc()
+	if err != nil {
+		g.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = g.ensureClusterRoleBinding(ctx); err != nil {
+		g.logger.Error(err, "cannot bind the GitOps identity ClusterRole")
+
+		return reconcile.Result{}, err
+	}
+
+	kubeconfig, err := g.identity(ctx, tcp)
+	if err != nil {
+		g.logger.Error(err, "cannot issue the GitOps identity")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = g.register(ctx, tcp, kubeconfig); err != nil {
+		g.logger.Error(err, "cannot register the Tenant Cluster in the GitOps tools")
+
+		return reconcile.Result{}, err
+	}
+
+	kc, err := utilities.DecodeKubeconfigYAML(kubeconfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	crt, err := crypto.ParseCertificateBytes(kc.AuthInfos[0].AuthInfo.ClientCertificateData)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// Enqueuing back the request to rotate the client certificate a day before its expiration,
+	// the same deadline used by the certificate lifecycle controller.
+	return reconcile.Result{RequeueAfter: time.Until(crt.NotAfter.AddDate(0, 0, -1))}, nil
+}
+
+// ensureClusterRoleBinding binds the GitOps identity group to the configured ClusterRole:
+// since the role reference is immutable, the binding is recreated upon a change of the ClusterRole.
+func (g *GitOps) ensureClusterRoleBinding(ctx context.Context) error {
+	binding := &rbacv1.ClusterRoleBinding{}
+	if err := g.client.Get(ctx, k8stypes.NamespacedName{Name: gitops.Identity}, binding); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if len(binding.GetUID()) > 0 && binding.RoleRef.Name != g.Registration.ClusterRole {
+		if err := g.client.Delete(ctx, binding); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	binding = &rbacv1.ClusterRoleBinding{}
+	binding.SetName(gitops.Identity)
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, g.client, binding, func() error {
+		binding.SetLabels(utilities.MergeMaps(binding.GetLabels(), map[string]string{
+			constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+			constants.ControlPlaneLabelResource: gitOpsComponent,
+		}))
+		binding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     g.Registration.ClusterRole,
+		}
+		binding.Subjects = []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     gitops.Identity,
+			},
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// identity returns the kubeconfig of the GitOps identity, stored in a Secret of the Tenant Control Plane namespace:
+// the client certificate is issued again upon a change of the CA, or of the endpoint, and when it's near to expiration.
+func (g *GitOps) identity(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	if len(tcp.Status.ControlPlaneEndpoint) == 0 {
+		return nil, fmt.Errorf("the Tenant Control Plane endpoint is not yet available")
+	}
+
+	ca := &corev1.Secret{}
+	if err := g.AdminClient.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.CA.SecretName}, ca); err != nil {
+		return nil, fmt.Errorf("cannot retrieve the CA Secret: %w", err)
+	}
+
+	checksum := utilities.CalculateMapChecksum(map[string][]byte{
+		"ca-cert-checksum": ca.Data[kubeadmconstants.CACertName],
+		"ca-key-checksum":  ca.Data[kubeadmconstants.CAKeyName],
+		"endpoint":         []byte(tcp.Status.ControlPlaneEndpoint),
+	})
+
+	secret := &corev1.Secret{}
+	secret.SetNamespace(tcp.GetNamespace())
+	secret.SetName(fmt.Sprintf("%s-%s-kubeconfig", tcp.GetName(), gitOpsComponent))
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, g.AdminClient, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), gitOpsComponent)))
+
+		if secret.GetAnnotations()[constants.Checksum] != checksum || !kubeadm.IsKubeconfigValid(secret.Data[gitOpsKubeconfigKey]) {
+			kubeconfig, kcErr := gitops.Kubeconfig(gitops.SecretName(tcp), "https://"+tcp.Status.ControlPlaneEndpoint, ca.Data[kubeadmconstants.CACertName], ca.Data[kubeadmconstants.CAKeyName])
+			if kcErr != nil {
+				return kcErr
+			}
+
+			secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{constants.Checksum: checksum}))
+			secret.Data = map[string][]byte{gitOpsKubeconfigKey: kubeconfig}
+		}
+
+		return controllerutil.SetControllerReference(tcp, secret, g.AdminClient.Scheme())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secret.Data[gitOpsKubeconfigKey], nil
+}
+
+// register writes the Tenant Cluster registration Secrets in the namespaces of the GitOps tools:
+// these cannot be owned by the Tenant Control Plane due to the cross-namespace reference, thus they're deleted upon its deletion.
+func (g *GitOps) register(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, kubeconfig []byte) error {
+	if namespace := g.Registration.ArgoCDNamespace; len(namespace) > 0 {
+		data, err := gitops.ArgoCDClusterData(gitops.SecretName(tcp), kubeconfig)
+		if err != nil {
+			return err
+		}
+
+		if err = g.writeSecret(ctx, tcp, namespace, map[string]string{gitops.ArgoCDSecretTypeLabel: gitops.ArgoCDSecretTypeCluster}, data); err != nil {
+			return fmt.Errorf("cannot write the Argo CD cluster Secret: %w", err)
+		}
+	}
+
+	if namespace := g.Registration.FluxNamespace; len(namespace) > 0 {
+		if err := g.writeSecret(ctx, tcp, namespace, nil, map[string][]byte{gitops.FluxKubeconfigKey: kubeconfig}); err != nil {
+			return fmt.Errorf("cannot write the Flux kubeconfig Secret: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (g *GitOps) writeSecret(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, namespace string, labels map[string]string, data map[string][]byte) error {
+	secret := &corev1.Secret{}
+	secret.SetNamespace(namespace)
+	secret.SetName(gitops.SecretName(tcp))
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, g.AdminClient, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), gitOpsComponent), labels))
+		secret.Data = data
+
+		return nil
+	})
+
+	return err
+}
+
+func (g *GitOps) SetupWithManager(mgr manager.Manager) error {
+	g.client = mgr.GetClient()
+	g.logger = mgr.GetLogger().WithName("gitops")
+	g.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&rbacv1.ClusterRoleBinding{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == gitops.Identity
+		}))).
+		Watches(&source.Channel{Source: g.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(g)
+}
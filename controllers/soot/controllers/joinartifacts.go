@@ -0,0 +1,257 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	certutil "k8s.io/client-go/util/cert"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	joinArtifactsComponent = "join-artifacts"
+	joinArtifactsTokenKey  = "token"
+	joinArtifactsHashKey   = "ca-cert-hash"
+)
+
+// JoinArtifacts renders the artifacts required by the worker nodes to join the Tenant Cluster in a Secret,
+// upon request using the join artifacts annotation: each request creates a short-lived bootstrap token,
+// revoking the one of the previous request.
+type JoinArtifacts struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	TokenTTL                  time.Duration
+
+	client client.Client
+	logger logr.Logger
+}
+
+func (j *JoinArtifacts) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := j.GetTenantControlPlaneFunc()
+	if err != nil {
+		j.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	secret := &corev1.Secret{}
+	if err = j.AdminClient.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: j.secretName(tcp)}, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			j.logger.Error(err, "cannot retrieve the join artifacts Secret")
+
+			return reconcile.Result{}, err
+		}
+
+		secret = nil
+	}
+
+	request := tcp.GetAnnotations()[constants.JoinArtifacts]
+	if len(request) == 0 {
+		if secret == nil {
+			return reconcile.Result{}, nil
+		}
+
+		if err = j.cleanup(ctx, secret); err != nil {
+			j.logger.Error(err, "cannot clean up the join artifacts")
+
+			return reconcile.Result{}, err
+		}
+
+		j.logger.Info("join artifacts removed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if secret != nil && secret.GetAnnotations()[constants.JoinArtifacts] == request {
+		return reconcile.Result{}, nil
+	}
+
+	if err = j.render(ctx, tcp, secret, request); err != nil {
+		j.logger.Error(err, "cannot render the join artifacts")
+
+		return reconcile.Result{}, err
+	}
+
+	j.logger.Info("join artifacts rendered", "request", request)
+
+	return reconcile.Result{}, nil
+}
+
+func (j *JoinArtifacts) render(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, previous *corev1.Secret, request string) error {
+	if len(tcp.Status.ControlPlaneEndpoint) == 0 {
+		return fmt.Errorf("the Tenant Control Plane endpoint is not yet available")
+	}
+
+	caCertHash, err := j.caCertHash(ctx, tcp)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		if err = j.revokeToken(ctx, previous); err != nil {
+			return err
+		}
+	}
+
+	token, expiration, err := j.createToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := kubeadm.JoinArtifacts(kubeadm.JoinParameters{
+		APIServerEndpoint: tcp.Status.ControlPlaneEndpoint,
+		Token:             token,
+		CACertHash:        caCertHash,
+		CGroupDriver:      tcp.Spec.Kubernetes.Kubelet.CGroupFS.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	artifacts[joinArtifactsTokenKey] = []byte(token)
+	artifacts[joinArtifactsHashKey] = []byte(caCertHash)
+
+	secret := &corev1.Secret{}
+	secret.SetNamespace(tcp.GetNamespace())
+	secret.SetName(j.secretName(tcp))
+
+	_, err = utilities.CreateOrUpdateWithConflict(ctx, j.AdminClient, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), joinArtifactsComponent)))
+		secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{
+			constants.JoinArtifacts:       request,
+			constants.JoinTokenExpiration: expiration.Format(time.RFC3339),
+		}))
+
+		secret.Data = artifacts
+
+		return controllerutil.SetControllerReference(tcp, secret, j.AdminClient.Scheme())
+	})
+
+	return err
+}
+
+// caCertHash returns the public key pin of the Tenant Control Plane CA, used by the nodes to validate the discovery.
+func (j *JoinArtifacts) caCertHash(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	secret := &corev1.Secret{}
+	if err := j.AdminClient.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.CA.SecretName}, secret); err != nil {
+		return "", fmt.Errorf("cannot retrieve the CA Secret: %w", err)
+	}
+
+	certs, err := certutil.ParseCertsPEM(secret.Data[kubeadmconstants.CACertName])
+	if err != nil {
+		return "", fmt.Errorf("cannot parse the CA certificate: %w", err)
+	}
+
+	return pubkeypin.Hash(certs[0]), nil
+}
+
+// createToken creates a bootstrap token in the Tenant Cluster, authenticating as the kubeadm default node token group:
+// the expired tokens are deleted by the controller-manager token cleaner.
+func (j *JoinArtifacts) createToken(ctx context.Context) (string, time.Time, error) {
+	value, err := bootstraputil.GenerateBootstrapToken()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("cannot generate the bootstrap token: %w", err)
+	}
+
+	tokenString, err := bootstraptokenv1.NewBootstrapTokenString(value)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	token := &bootstraptokenv1.BootstrapToken{
+		Token:       tokenString,
+		Description: "Bootstrap token generated by Kamaji for the join artifacts.",
+		TTL:         &metav1.Duration{Duration: j.TokenTTL},
+		Usages:      kubeadmconstants.DefaultTokenUsages,
+		Groups:      kubeadmconstants.DefaultTokenGroups,
+	}
+
+	secret := bootstraptokenv1.BootstrapTokenToSecret(token)
+	secret.SetLabels(map[string]string{
+		constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+		constants.ControlPlaneLabelResource: joinArtifactsComponent,
+	})
+
+	if err = j.client.Create(ctx, secret); err != nil {
+		return "", time.Time{}, fmt.Errorf("cannot create the bootstrap token: %w", err)
+	}
+
+	return value, time.Now().Add(j.TokenTTL), nil
+}
+
+// revokeToken deletes from the Tenant Cluster the bootstrap token of the given join artifacts, if not yet expired.
+func (j *JoinArtifacts) revokeToken(ctx context.Context, artifacts *corev1.Secret) error {
+	tokenID, _, found := strings.Cut(string(artifacts.Data[joinArtifactsTokenKey]), ".")
+	if !found {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	secret.SetNamespace(metav1.NamespaceSystem)
+	secret.SetName(bootstraputil.BootstrapTokenSecretName(tokenID))
+
+	if err := j.client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot revoke the bootstrap token: %w", err)
+	}
+
+	return nil
+}
+
+func (j *JoinArtifacts) cleanup(ctx context.Context, secret *corev1.Secret) error {
+	if err := j.revokeToken(ctx, secret); err != nil {
+		return err
+	}
+
+	if err := j.AdminClient.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (j *JoinArtifacts) secretName(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("%s-%s", tcp.GetName(), joinArtifactsComponent)
+}
+
+func (j *JoinArtifacts) SetupWithManager(mgr manager.Manager) error {
+	j.client = mgr.GetClient()
+	j.logger = mgr.GetLogger().WithName("join_artifacts")
+	j.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == metav1.NamespaceSystem && object.GetLabels()[constants.ControlPlaneLabelResource] == joinArtifactsComponent
+		}))).
+		Watches(&source.Channel{Source: j.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(j)
+}
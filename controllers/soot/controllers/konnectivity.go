@@ -24,6 +24,7 @@ import (
 
 	"github.com/clastix/kamaji/controllers"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/konnectivity"
 )
@@ -34,6 +35,7 @@ type KonnectivityAgent struct {
 	AdminClient               client.Client
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	Images                    images.Policy
 }
 
 func (k *KonnectivityAgent) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
@@ -44,7 +46,7 @@ func (k *KonnectivityAgent) Reconcile(ctx context.Context, _ reconcile.Request)
 		return reconcile.Result{}, err
 	}
 
-	for _, resource := range controllers.GetExternalKonnectivityResources(k.AdminClient) {
+	for _, resource := range controllers.GetExternalKonnectivityResources(k.AdminClient, k.Images) {
 		k.logger.Info("start processing", "resource", resource.GetName())
 
 		result, handlingErr := resources.Handle(ctx, resource, tcp)
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/addons"
@@ -31,6 +32,7 @@ type KubeProxy struct {
 	AdminClient               client.Client
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	Images                    images.Policy
 
 	logger logr.Logger
 }
@@ -45,7 +47,7 @@ func (k *KubeProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconci
 
 	k.logger.Info("start processing")
 
-	resource := &addons.KubeProxy{Client: k.AdminClient}
+	resource := &addons.KubeProxy{Client: k.AdminClient, Images: k.Images}
 
 	result, handlingErr := resources.Handle(ctx, resource, tcp)
 	if handlingErr != nil {
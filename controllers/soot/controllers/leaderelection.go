@@ -0,0 +1,150 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+const (
+	kubeControllerManagerLeaseName = "kube-controller-manager"
+	kubeSchedulerLeaseName         = "kube-scheduler"
+)
+
+// LeaderElection reports in the Tenant Control Plane status the leaders of the controller-manager, and the scheduler,
+// according to the Leases in the Tenant Cluster.
+type LeaderElection struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	client client.Client
+	logger logr.Logger
+}
+
+func (l *LeaderElection) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := l.GetTenantControlPlaneFunc()
+	if err != nil {
+		l.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	var status kamajiv1alpha1.LeaderElectionStatus
+
+	if status.ControllerManager, err = l.leader(ctx, kubeControllerManagerLeaseName); err != nil {
+		l.logger.Error(err, "cannot retrieve the Lease", "name", kubeControllerManagerLeaseName)
+
+		return reconcile.Result{}, err
+	}
+
+	if status.Scheduler, err = l.leader(ctx, kubeSchedulerLeaseName); err != nil {
+		l.logger.Error(err, "cannot retrieve the Lease", "name", kubeSchedulerLeaseName)
+
+		return reconcile.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(tcp.Status.LeaderElection, status) {
+		return reconcile.Result{}, nil
+	}
+
+	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp, err = l.GetTenantControlPlaneFunc()
+		if err != nil {
+			return err
+		}
+
+		tcp.Status.LeaderElection = status
+
+		return l.AdminClient.Status().Update(ctx, tcp)
+	}); err != nil {
+		l.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	l.logger.Info("leaders updated", "controllerManager", status.ControllerManager.Pod, "scheduler", status.Scheduler.Pod)
+
+	return reconcile.Result{}, nil
+}
+
+// leader returns the status of the leader holding the Lease with the given name,
+// which is empty if the Lease is not yet created, or not held.
+func (l *LeaderElection) leader(ctx context.Context, name string) (kamajiv1alpha1.ComponentLeaderStatus, error) {
+	lease := &coordinationv1.Lease{}
+	if err := l.client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, lease); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return kamajiv1alpha1.ComponentLeaderStatus{}, nil
+		}
+
+		return kamajiv1alpha1.ComponentLeaderStatus{}, err
+	}
+
+	var status kamajiv1alpha1.ComponentLeaderStatus
+
+	if holder := lease.Spec.HolderIdentity; holder != nil && len(*holder) > 0 {
+		status.HolderIdentity = *holder
+		// The holder identity is composed of the hostname, which is the pod name, and a unique suffix.
+		status.Pod, _, _ = strings.Cut(*holder, "_")
+	}
+
+	if lease.Spec.LeaseTransitions != nil {
+		status.LeaseTransitions = *lease.Spec.LeaseTransitions
+	}
+
+	if lease.Spec.AcquireTime != nil {
+		status.LastTransitionTime = metav1.NewTime(lease.Spec.AcquireTime.Time).Rfc3339Copy()
+	}
+
+	return status, nil
+}
+
+func (l *LeaderElection) SetupWithManager(mgr manager.Manager) error {
+	l.client = mgr.GetClient()
+	l.logger = mgr.GetLogger().WithName("leader_election")
+	l.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&coordinationv1.Lease{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(object client.Object) bool {
+				if object.GetNamespace() != metav1.NamespaceSystem {
+					return false
+				}
+
+				return object.GetName() == kubeControllerManagerLeaseName || object.GetName() == kubeSchedulerLeaseName
+			}),
+			// The Leases are renewed every few seconds: reconciling only upon a leader change.
+			predicate.Funcs{
+				UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+					oldLease, newLease := updateEvent.ObjectOld.(*coordinationv1.Lease), updateEvent.ObjectNew.(*coordinationv1.Lease) //nolint:forcetypeassert
+
+					return !equality.Semantic.DeepEqual(oldLease.Spec.HolderIdentity, newLease.Spec.HolderIdentity) ||
+						!equality.Semantic.DeepEqual(oldLease.Spec.AcquireTime, newLease.Spec.AcquireTime)
+				},
+			},
+		)).
+		Watches(&source.Channel{Source: l.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(l)
+}
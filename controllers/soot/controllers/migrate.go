@@ -51,7 +51,7 @@ func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 	switch *tcp.Status.Kubernetes.Version.Status {
 	case v1alpha1.VersionMigrating:
 		err = m.createOrUpdate(ctx)
-	case v1alpha1.VersionReady:
+	case v1alpha1.VersionReady, v1alpha1.VersionAddonsNotReady:
 		err = m.cleanup(ctx)
 	}
 
@@ -6,6 +6,7 @@ package soot
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
@@ -27,13 +28,19 @@ import (
 	"github.com/clastix/kamaji/controllers/finalizers"
 	"github.com/clastix/kamaji/controllers/soot/controllers"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
 type sootItem struct {
 	triggers []chan event.GenericEvent
 	cancelFn context.CancelFunc
+	// flowControl tracks if the flow control controller has been registered,
+	// depending on the Kubernetes version of the Tenant Control Plane.
+	flowControl bool
 }
 
 type sootMap map[string]sootItem
@@ -49,6 +56,12 @@ type Manager struct {
 	MigrateServiceName      string
 	MigrateServiceNamespace string
 	AdminClient             client.Client
+	// Images is the operator-level policy applied to the images of the addons deployed in the Tenant Clusters.
+	Images images.Policy
+	// JoinTokenTTL is the validity of the bootstrap tokens created for the join artifacts.
+	JoinTokenTTL time.Duration
+	// GitOps is the registration of the Tenant Clusters in the GitOps tools, disabled when no namespace is configured.
+	GitOps gitops.Registration
 }
 
 // retrieveTenantControlPlane is the function used to let an underlying controller of the soot manager
@@ -128,11 +141,15 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 			// The TenantControlPlane CA has been rotated, it means the running manager
 			// must be restarted to avoid certificate signed by unknown authority errors.
 			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
-		case tcpStatus == kamajiv1alpha1.VersionNotReady:
-			// The TenantControlPlane is in non-ready mode, or marked for deletion:
+		case tcpStatus == kamajiv1alpha1.VersionNotReady, tcpStatus == kamajiv1alpha1.VersionSleeping:
+			// The TenantControlPlane is in non-ready mode, scaled to zero, or marked for deletion:
 			// we don't want to pollute with messages due to broken connection.
 			// Once the TCP will be ready again, the event will be intercepted and the manager started back.
 			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
+		case v.flowControl != addons.FlowControlSupported(tcp.Status.Kubernetes.Version.Version):
+			// The TenantControlPlane has been upgraded to a version serving the flow control API:
+			// the manager must be restarted to register the missing controller.
+			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
 		default:
 			for _, trigger := range v.triggers {
 				trigger <- event.GenericEvent{Object: tcp}
@@ -143,7 +160,8 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	}
 	// No need to start a soot manager if the TenantControlPlane is not ready:
 	// enqueuing back is not required since we're going to get that event once ready.
-	if tcpStatus == kamajiv1alpha1.VersionNotReady || tcpStatus == kamajiv1alpha1.VersionCARotating {
+	// The AddonsNotReady ones are serving the API Server, and require the soot manager to observe the addons rollout.
+	if tcpStatus == kamajiv1alpha1.VersionNotReady || tcpStatus == kamajiv1alpha1.VersionCARotating || tcpStatus == kamajiv1alpha1.VersionSleeping {
 		log.FromContext(ctx).Info("skipping start of the soot manager for a not ready instance")
 
 		return reconcile.Result{}, nil
@@ -203,6 +221,7 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	konnectivityAgent := &controllers.KonnectivityAgent{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Images:                    m.Images,
 	}
 	if err = konnectivityAgent.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
@@ -211,6 +230,7 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	kubeProxy := &controllers.KubeProxy{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Images:                    m.Images,
 	}
 	if err = kubeProxy.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
@@ -219,11 +239,62 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	coreDNS := &controllers.CoreDNS{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Images:                    m.Images,
 	}
 	if err = coreDNS.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	addonsReadiness := &controllers.AddonsReadiness{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = addonsReadiness.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	leaderElection := &controllers.LeaderElection{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = leaderElection.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	joinArtifacts := &controllers.JoinArtifacts{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		TokenTTL:                  m.JoinTokenTTL,
+	}
+	if err = joinArtifacts.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var gitOps *controllers.GitOps
+	if m.GitOps.Enabled() {
+		gitOps = &controllers.GitOps{
+			AdminClient:               m.AdminClient,
+			GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+			Registration:              m.GitOps,
+		}
+		if err = gitOps.SetupWithManager(mgr); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	var flowControl *controllers.FlowControl
+	// The FlowSchema and PriorityLevelConfiguration API version used by the addon is served starting from v1.26:
+	// the informers of older Tenant Clusters would never sync, thus the controller is registered only if supported.
+	if addons.FlowControlSupported(tcp.Status.Kubernetes.Version.Version) {
+		flowControl = &controllers.FlowControl{
+			AdminClient:               m.AdminClient,
+			GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		}
+		if err = flowControl.SetupWithManager(mgr); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	uploadKubeadmConfig := &controllers.KubeadmPhase{
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
 		Phase: &resources.KubeadmPhase{
@@ -267,17 +338,31 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		}
 	}()
 
+	triggers := []chan event.GenericEvent{
+		migrate.TriggerChannel,
+		konnectivityAgent.TriggerChannel,
+		kubeProxy.TriggerChannel,
+		coreDNS.TriggerChannel,
+		addonsReadiness.TriggerChannel,
+		leaderElection.TriggerChannel,
+		joinArtifacts.TriggerChannel,
+		uploadKubeadmConfig.TriggerChannel,
+		uploadKubeletConfig.TriggerChannel,
+		bootstrapToken.TriggerChannel,
+	}
+
+	if gitOps != nil {
+		triggers = append(triggers, gitOps.TriggerChannel)
+	}
+
+	if flowControl != nil {
+		triggers = append(triggers, flowControl.TriggerChannel)
+	}
+
 	m.sootMap[request.NamespacedName.String()] = sootItem{
-		triggers: []chan event.GenericEvent{
-			migrate.TriggerChannel,
-			konnectivityAgent.TriggerChannel,
-			kubeProxy.TriggerChannel,
-			coreDNS.TriggerChannel,
-			uploadKubeadmConfig.TriggerChannel,
-			uploadKubeletConfig.TriggerChannel,
-			bootstrapToken.TriggerChannel,
-		},
-		cancelFn: tcpCancelFn,
+		triggers:    triggers,
+		cancelFn:    tcpCancelFn,
+		flowControl: flowControl != nil,
 	}
 
 	return reconcile.Result{Requeue: true}, nil
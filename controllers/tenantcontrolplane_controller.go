@@ -16,7 +16,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,8 +39,13 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/finalizers"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/activator"
+	"github.com/clastix/kamaji/internal/builders/controlplane"
 	"github.com/clastix/kamaji/internal/datastore"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/images"
+	"github.com/clastix/kamaji/internal/priorityqueue"
 	"github.com/clastix/kamaji/internal/resources"
 )
 
@@ -50,12 +60,18 @@ type TenantControlPlaneReconciler struct {
 	KamajiService           string
 	KamajiMigrateImage      string
 	MaxConcurrentReconciles int
+	// RateLimiter is applied upon the requeue of the failed reconciliations.
+	RateLimiter RateLimiterConfig
 	// CertificateChan is the channel used by the CertificateLifecycleController that is checking for
 	// certificates and kubeconfig user certs validity: a generic event for the given TCP will be triggered
 	// once the validity threshold for the given certificate is reached.
 	CertificateChan CertificateChannel
+	// Activator is serving the connections of the Tenant Control Planes scaled to zero due to inactivity,
+	// when nil the idle policies are not enforced.
+	Activator *activator.Activator
 
-	clock mutex.Clock
+	clock    mutex.Clock
+	recorder record.EventRecorder
 }
 
 // TenantControlPlaneReconcilerConfig gives the necessary configuration for TenantControlPlaneReconciler.
@@ -64,20 +80,51 @@ type TenantControlPlaneReconcilerConfig struct {
 	DefaultDataStoreName string
 	KineContainerImage   string
 	TmpBaseDirectory     string
+	// ResourceTimeout is the maximum duration of the handling of a single resource,
+	// such as the DataStore setup, or the kubeadm phases: when zero, it's bounded by the reconcile timeout only.
+	ResourceTimeout time.Duration
+	// ResourceTimeouts overrides the ResourceTimeout for the resources matching the given name.
+	ResourceTimeouts map[string]time.Duration
+	// Scheduling contains the scheduling defaults of the Tenant Control Plane pods.
+	Scheduling controlplane.Scheduling
+	// Images is the operator-level policy applied to the images rendered for the Tenant Control Plane.
+	Images images.Policy
+	// GitOps is the registration of the Tenant Clusters in the GitOps tools, removed upon the Tenant Control Plane deletion.
+	GitOps gitops.Registration
+}
+
+// resourceContext returns the context used for the handling of the given resource, bounded by its timeout.
+func (in TenantControlPlaneReconcilerConfig) resourceContext(ctx context.Context, name string) (context.Context, context.CancelFunc) {
+	timeout, ok := in.ResourceTimeouts[name]
+	if !ok {
+		timeout = in.ResourceTimeout
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=controlplanesizes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;create;update;patch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
+	// The manager context is retained to report the timed out resources, since the reconciliation one is expired.
+	managerCtx := ctx
 
 	var cancelFn context.CancelFunc
 	ctx, cancelFn = context.WithTimeout(ctx, r.Config.ReconcileTimeout)
@@ -88,6 +135,8 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if apimachineryerrors.IsNotFound(err) {
 			log.Info("resource may have been deleted, skipping")
 
+			notReady.forget(req.NamespacedName)
+
 			return ctrl.Result{}, nil
 		}
 
@@ -115,6 +164,8 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 	defer releaser.Release()
 
+	notReady.observe(tenantControlPlane)
+
 	markedToBeDeleted := tenantControlPlane.GetDeletionTimestamp() != nil
 
 	if markedToBeDeleted && !controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
@@ -171,12 +222,29 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		KamajiServiceAccount: r.KamajiServiceAccount,
 		KamajiService:        r.KamajiService,
 		KamajiMigrateImage:   r.KamajiMigrateImage,
+		Activator:            r.Activator,
 	}
 	registeredResources := GetResources(groupResourceBuilderConfiguration)
 
 	for _, resource := range registeredResources {
-		result, err := resources.Handle(ctx, resource, tenantControlPlane)
+		resourceCtx, resourceCancelFn := r.Config.resourceContext(ctx, resource.GetName())
+
+		result, err := resources.Handle(resourceCtx, resource, tenantControlPlane)
+		timedOut := errors.Is(resourceCtx.Err(), context.DeadlineExceeded)
+
+		resourceCancelFn()
+
 		if err != nil {
+			if timedOut {
+				log.Error(err, "handling of resource timed out", "resource", resource.GetName())
+
+				if reportErr := r.reportResourceTimeout(managerCtx, tenantControlPlane, resource.GetName()); reportErr != nil {
+					log.Error(reportErr, "cannot report the resource timeout", "resource", resource.GetName())
+				}
+
+				return ctrl.Result{}, err
+			}
+
 			if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
 				log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
 
@@ -207,11 +275,67 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
+	notReady.observe(tenantControlPlane)
+
+	if err = r.updateReconciledCondition(ctx, req.NamespacedName, metav1.Condition{
+		Type:    kamajiv1alpha1.ConditionTypeReconciled,
+		Status:  metav1.ConditionTrue,
+		Reason:  kamajiv1alpha1.ReconciledReasonSucceeded,
+		Message: "all the resources have been reconciled",
+	}); err != nil {
+		log.Error(err, "cannot update the reconciled condition")
+
+		return ctrl.Result{}, err
+	}
+
 	log.Info(fmt.Sprintf("%s has been reconciled", tenantControlPlane.GetName()))
 
 	return ctrl.Result{}, nil
 }
 
+// reportResourceTimeout records the resource which didn't complete within its timeout,
+// using a Warning event, and the Reconciled condition.
+func (r *TenantControlPlaneReconciler) reportResourceTimeout(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, name string) error {
+	resourceTimeouts.WithLabelValues(name).Inc()
+
+	message := fmt.Sprintf("the %s resource has not been reconciled within the timeout", name)
+
+	r.recorder.Event(tcp, corev1.EventTypeWarning, kamajiv1alpha1.ReconciledReasonResourceTimeout, message)
+
+	ctx, cancelFn := context.WithTimeout(ctx, r.Config.ReconcileTimeout)
+	defer cancelFn()
+
+	return r.updateReconciledCondition(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, metav1.Condition{
+		Type:    kamajiv1alpha1.ConditionTypeReconciled,
+		Status:  metav1.ConditionFalse,
+		Reason:  kamajiv1alpha1.ReconciledReasonResourceTimeout,
+		Message: message,
+	})
+}
+
+func (r *TenantControlPlaneReconciler) updateReconciledCondition(ctx context.Context, namespacedName k8stypes.NamespacedName, condition metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		if err := r.Client.Get(ctx, namespacedName, tcp); err != nil {
+			return err
+		}
+
+		condition.ObservedGeneration = tcp.GetGeneration()
+		// Avoiding useless status updates, since they would trigger the reconciliation of the Tenant Control Plane.
+		if current := meta.FindStatusCondition(tcp.Status.Conditions, condition.Type); current != nil &&
+			current.Status == condition.Status &&
+			current.Reason == condition.Reason &&
+			current.Message == condition.Message &&
+			current.ObservedGeneration == condition.ObservedGeneration {
+			return nil
+		}
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, condition)
+
+		return r.Client.Status().Update(ctx, tcp)
+	})
+}
+
 func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 	return mutex.Spec{
 		Name:    strings.ReplaceAll(fmt.Sprintf("kamaji%s", obj.GetUID()), "-", ""),
@@ -225,31 +349,60 @@ func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 // SetupWithManager sets up the controller with the Manager.
 func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.clock = clock.RealClock{}
+	r.recorder = mgr.GetEventRecorderFor("tenantcontrolplane")
+	// The reconciliation requests are dispatched according to the Tenant Control Plane priority class,
+	// the owned resources are watched explicitly since the builder doesn't allow wrapping their event handlers.
+	queue := priorityqueue.New("tenantcontrolplane", r.MaxConcurrentReconciles, r.priority)
+	if err := mgr.Add(queue); err != nil {
+		return err
+	}
+
+	enqueueOwner := func() handler.EventHandler {
+		return queue.Handler(&handler.EnqueueRequestForOwner{OwnerType: &kamajiv1alpha1.TenantControlPlane{}, IsController: true})
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		Watches(&source.Channel{Source: r.CertificateChan}, handler.Funcs{GenericFunc: func(genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
+		Named("tenantcontrolplane").
+		Watches(&source.Channel{Source: r.CertificateChan}, queue.Handler(handler.Funcs{GenericFunc: func(genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
 			limitingInterface.AddRateLimited(ctrl.Request{
 				NamespacedName: k8stypes.NamespacedName{
 					Namespace: genericEvent.Object.GetNamespace(),
 					Name:      genericEvent.Object.GetName(),
 				},
 			})
-		}}).
-		Watches(&source.Channel{Source: r.TriggerChan}, handler.Funcs{GenericFunc: func(genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
+		}})).
+		Watches(&source.Channel{Source: r.TriggerChan}, queue.Handler(handler.Funcs{GenericFunc: func(genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
 			limitingInterface.AddRateLimited(ctrl.Request{
 				NamespacedName: k8stypes.NamespacedName{
 					Namespace: genericEvent.Object.GetNamespace(),
 					Name:      genericEvent.Object.GetName(),
 				},
 			})
-		}}).
-		For(&kamajiv1alpha1.TenantControlPlane{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{}).
-		Owns(&networkingv1.Ingress{}).
-		Watches(&source.Kind{Type: &batchv1.Job{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		}})).
+		Watches(&source.Kind{Type: &kamajiv1alpha1.TenantControlPlane{}}, queue.Handler(&handler.EnqueueRequestForObject{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, enqueueOwner()).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, enqueueOwner()).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, enqueueOwner()).
+		Watches(&source.Kind{Type: &corev1.Service{}}, enqueueOwner()).
+		Watches(&source.Kind{Type: &networkingv1.Ingress{}}, enqueueOwner()).
+		Watches(&source.Kind{Type: &kamajiv1alpha1.ControlPlaneSize{}}, queue.Handler(handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+			if err := r.Client.List(context.Background(), tcpList, client.MatchingFieldsSelector{
+				Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedSizeKey, object.GetName()),
+			}); err != nil {
+				log.FromContext(context.Background()).Error(err, "cannot retrieve the Tenant Control Planes using the ControlPlaneSize", "size", object.GetName())
+
+				return nil
+			}
+
+			requests := make([]reconcile.Request, 0, len(tcpList.Items))
+			for _, tcp := range tcpList.Items {
+				requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}})
+			}
+
+			return requests
+		}))).
+		Watches(&source.Kind{Type: &batchv1.Job{}}, queue.Handler(handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
 			labels := object.GetLabels()
 
 			name, namespace := labels["tcp.kamaji.clastix.io/name"], labels["tcp.kamaji.clastix.io/namespace"]
@@ -262,7 +415,7 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 					},
 				},
 			}
-		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		})), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
 			if object.GetNamespace() != r.KamajiNamespace {
 				return false
 			}
@@ -279,8 +432,20 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 		}))).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             r.RateLimiter.RateLimiter(),
 		}).
-		Complete(r)
+		Complete(queue.Reconciler(r))
+}
+
+// priority returns the priority class declared by the Tenant Control Plane,
+// the normal one is used when it cannot be retrieved, e.g. upon its deletion.
+func (r *TenantControlPlaneReconciler) priority(ctx context.Context, request reconcile.Request) priorityqueue.Class {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.Client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		return priorityqueue.ClassNormal
+	}
+
+	return priorityqueue.ClassFor(tcp)
 }
 
 func (r *TenantControlPlaneReconciler) getTenantControlPlane(ctx context.Context, namespacedName k8stypes.NamespacedName) utils.TenantControlPlaneRetrievalFn {
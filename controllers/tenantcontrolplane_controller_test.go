@@ -0,0 +1,79 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestResourceContext(t *testing.T) {
+	config := TenantControlPlaneReconcilerConfig{
+		ResourceTimeout:  time.Minute,
+		ResourceTimeouts: map[string]time.Duration{"datastore-setup": time.Second, "upgrade": 0},
+	}
+
+	tests := []struct {
+		name        string
+		resource    string
+		hasDeadline bool
+		timeout     time.Duration
+	}{
+		{name: "default timeout", resource: "ca", hasDeadline: true, timeout: time.Minute},
+		{name: "overridden timeout", resource: "datastore-setup", hasDeadline: true, timeout: time.Second},
+		{name: "disabled timeout", resource: "upgrade"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancelFn := config.resourceContext(context.Background(), tt.resource)
+			defer cancelFn()
+
+			deadline, ok := ctx.Deadline()
+
+			switch {
+			case ok != tt.hasDeadline:
+				t.Fatalf("expected deadline %t, got %t", tt.hasDeadline, ok)
+			case ok && time.Until(deadline) > tt.timeout:
+				t.Fatalf("expected a deadline within %s, got %s", tt.timeout, time.Until(deadline))
+			}
+		})
+	}
+}
+
+func TestNotReadyTracker(t *testing.T) {
+	tracker := &notReadyTracker{since: map[k8stypes.NamespacedName]time.Time{}}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+	tcp.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionProvisioning
+
+	tracker.observe(tcp)
+
+	since := tracker.since[k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}]
+	// A subsequent observation of a non Ready state must not reset the tracking.
+	tcp.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionNotReady
+	tracker.observe(tcp)
+
+	if actual := tracker.since[k8stypes.NamespacedName{Namespace: "default", Name: "tenant"}]; !actual.Equal(since) {
+		t.Fatalf("expected the tracking to start at %s, got %s", since, actual)
+	}
+
+	if count := testutil.CollectAndCount(tracker); count != 1 {
+		t.Fatalf("expected a single metric, got %d", count)
+	}
+
+	tcp.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionReady
+	tracker.observe(tcp)
+
+	if count := testutil.CollectAndCount(tracker); count != 0 {
+		t.Fatalf("expected no metrics for a Ready Tenant Control Plane, got %d", count)
+	}
+}
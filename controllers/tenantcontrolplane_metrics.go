@@ -0,0 +1,77 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var (
+	resourceTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kamaji_tenant_control_plane_resource_timeouts_total",
+		Help: "Total number of Tenant Control Plane resources which have not been reconciled within their timeout.",
+	}, []string{"resource"})
+	notReadyDuration = prometheus.NewDesc(
+		"kamaji_tenant_control_plane_not_ready_duration_seconds",
+		"Time elapsed since the Tenant Control Plane has been observed in a non Ready state.",
+		[]string{"namespace", "name"}, nil,
+	)
+
+	notReady = &notReadyTracker{since: map[k8stypes.NamespacedName]time.Time{}}
+)
+
+func init() {
+	metrics.Registry.MustRegister(resourceTimeouts, notReady)
+}
+
+// notReadyTracker keeps track of the Tenant Control Planes which are not Ready, such as the ones stuck in the provisioning,
+// exposing for how long upon the metrics collection: the tracking starts when the controller observes them for the first time.
+type notReadyTracker struct {
+	mu    sync.Mutex
+	since map[k8stypes.NamespacedName]time.Time
+}
+
+func (t *notReadyTracker) observe(tcp *kamajiv1alpha1.TenantControlPlane) {
+	namespacedName := k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}
+
+	if status := tcp.Status.Kubernetes.Version.Status; status != nil && (*status == kamajiv1alpha1.VersionReady || *status == kamajiv1alpha1.VersionSleeping) {
+		t.forget(namespacedName)
+
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.since[namespacedName]; !ok {
+		t.since[namespacedName] = time.Now()
+	}
+}
+
+func (t *notReadyTracker) forget(namespacedName k8stypes.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.since, namespacedName)
+}
+
+func (t *notReadyTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- notReadyDuration
+}
+
+func (t *notReadyTracker) Collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for namespacedName, since := range t.since {
+		ch <- prometheus.MustNewConstMetric(notReadyDuration, prometheus.GaugeValue, time.Since(since).Seconds(), namespacedName.Namespace, namespacedName.Name)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package activator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Activator is serving the connections of the Tenant Control Planes scaled to zero due to inactivity:
+// each of them gets a dedicated listener, the Tenant Control Plane Service Endpoints are pointing to.
+// Upon the first incoming connection the Tenant Control Plane is woken up, and the held connections
+// are forwarded to the API Server once ready.
+// It implements the controller-runtime manager.Runnable interface, and it must run on the leader
+// since the Endpoints are managed by the Tenant Control Plane reconciler: the listeners are bound to random ports,
+// and they're started again by the new leader upon the reconciliation of the Tenant Control Planes.
+type Activator struct {
+	Client client.Client
+	// Address is the IP address of the current Kamaji instance, used by the Endpoints of the Tenant Control Planes.
+	Address string
+	// WakeUpTimeout is the maximum duration a connection is held, waiting for the API Server to be ready.
+	WakeUpTimeout time.Duration
+	DialTimeout   time.Duration
+	Log           logr.Logger
+
+	mu        sync.Mutex
+	ctx       context.Context
+	cancelFn  context.CancelFunc
+	listeners map[k8stypes.NamespacedName]net.Listener
+}
+
+func (a *Activator) NeedLeaderElection() bool {
+	return true
+}
+
+func (a *Activator) Start(ctx context.Context) error {
+	a.mu.Lock()
+	a.init()
+	a.mu.Unlock()
+
+	<-ctx.Done()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for tcp, listener := range a.listeners {
+		_ = listener.Close()
+
+		delete(a.listeners, tcp)
+	}
+
+	a.cancelFn()
+
+	return nil
+}
+
+// Listen returns the port of the listener serving the connections for the given Tenant Control Plane,
+// starting it if not yet running.
+func (a *Activator) Listen(tcp k8stypes.NamespacedName) (int32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.init()
+
+	listener, ok := a.listeners[tcp]
+	if !ok {
+		var err error
+
+		if listener, err = net.Listen("tcp", net.JoinHostPort("", "0")); err != nil {
+			return 0, errors.Wrap(err, "cannot start the activator listener")
+		}
+
+		a.listeners[tcp] = listener
+
+		go a.serve(tcp, listener)
+	}
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve the activator listener port")
+	}
+
+	value, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse the activator listener port")
+	}
+
+	return int32(value), nil
+}
+
+// Close stops the listener of the given Tenant Control Plane:
+// the connections already accepted are still served until completion.
+func (a *Activator) Close(tcp k8stypes.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if listener, ok := a.listeners[tcp]; ok {
+		_ = listener.Close()
+
+		delete(a.listeners, tcp)
+	}
+}
+
+func (a *Activator) init() {
+	if a.listeners == nil {
+		a.listeners = map[k8stypes.NamespacedName]net.Listener{}
+	}
+
+	if a.ctx == nil {
+		a.ctx, a.cancelFn = context.WithCancel(context.Background())
+	}
+}
+
+func (a *Activator) serve(tcp k8stypes.NamespacedName, listener net.Listener) {
+	log := a.Log.WithValues("tenantControlPlane", tcp.String())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.V(1).Info("activator listener stopped", "reason", err.Error())
+
+			return
+		}
+
+		go a.handle(log, tcp, conn)
+	}
+}
+
+func (a *Activator) handle(log logr.Logger, tcp k8stypes.NamespacedName, conn net.Conn) {
+	defer conn.Close()
+
+	wakeUpCtx, cancelFn := context.WithTimeout(a.ctx, a.WakeUpTimeout)
+	defer cancelFn()
+
+	backend, err := a.wakeUp(wakeUpCtx, tcp)
+	if err != nil {
+		log.Error(err, "cannot wake up the Tenant Control Plane")
+
+		return
+	}
+
+	dialer := net.Dialer{Timeout: a.DialTimeout}
+
+	upstream, err := dialer.DialContext(a.ctx, "tcp", backend)
+	if err != nil {
+		log.Error(err, "cannot connect to the Tenant Control Plane API Server", "backend", backend)
+
+		return
+	}
+	defer upstream.Close()
+
+	errCh := make(chan error, 2)
+
+	go pipe(upstream, conn, errCh)
+	go pipe(conn, upstream, errCh)
+	// Waiting for both directions to be completed, or the context cancellation.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-errCh:
+		}
+	}
+}
+
+// wakeUp marks the Tenant Control Plane as waking up, letting the reconciler scale back the Deployment,
+// and waits for an API Server Pod to be ready, returning its address.
+func (a *Activator) wakeUp(ctx context.Context, namespacedName k8stypes.NamespacedName) (string, error) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := a.Client.Get(ctx, namespacedName, tcp); err != nil {
+			return err
+		}
+
+		if !meta.IsStatusConditionTrue(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle) {
+			return nil
+		}
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.ConditionTypeIdle,
+			Status:             metav1.ConditionFalse,
+			Reason:             kamajiv1alpha1.IdleReasonWakingUp,
+			Message:            "a connection has been received, scaling the Tenant Control Plane back up",
+			ObservedGeneration: tcp.GetGeneration(),
+		})
+
+		return a.Client.Status().Update(ctx, tcp)
+	}); err != nil {
+		return "", errors.Wrap(err, "cannot update the Tenant Control Plane idle condition")
+	}
+
+	var backend string
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		pods := &corev1.PodList{}
+		if err := a.Client.List(ctx, pods, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels{"kamaji.clastix.io/name": tcp.GetName()}); err != nil {
+			return false, err
+		}
+
+		for _, pod := range pods.Items {
+			if pod.GetDeletionTimestamp() != nil || len(pod.Status.PodIP) == 0 || !isPodReady(pod) {
+				continue
+			}
+
+			backend = net.JoinHostPort(pod.Status.PodIP, fmt.Sprintf("%d", tcp.Spec.NetworkProfile.Port))
+
+			return true, nil
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "the Tenant Control Plane API Server is not ready")
+	}
+
+	return backend, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func pipe(dst, src net.Conn, errCh chan<- error) {
+	_, err := io.Copy(dst, src)
+	// Propagating the half-close to the other peer, if supported.
+	if tcpConn, ok := dst.(*net.TCPConn); ok {
+		_ = tcpConn.CloseWrite()
+	}
+
+	errCh <- err
+}
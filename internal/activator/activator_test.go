@@ -0,0 +1,154 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package activator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestActivatorListen(t *testing.T) {
+	activator := &Activator{Log: logr.Discard()}
+
+	tenant00, tenant01 := k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"}, k8stypes.NamespacedName{Namespace: "default", Name: "tenant-01"}
+
+	port, err := activator.Listen(tenant00)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if again, _ := activator.Listen(tenant00); again != port {
+		t.Fatalf("expected the listener to be reused, got port %d instead of %d", again, port)
+	}
+
+	other, err := activator.Listen(tenant01)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if other == port {
+		t.Fatal("expected a dedicated listener for each Tenant Control Plane")
+	}
+
+	activator.Close(tenant00)
+
+	if _, err = net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port))), time.Second); err == nil {
+		t.Fatal("expected the listener to be closed")
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+	// Stopping the activator, such as upon the leader election loss, is closing all the listeners.
+	if err = activator.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err = net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(other))), time.Second); err == nil {
+		t.Fatal("expected the listeners to be closed upon stop")
+	}
+}
+
+func TestActivatorWakeUp(t *testing.T) {
+	// The backend is echoing the received data, acting as the API Server.
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	go func() {
+		for {
+			conn, acceptErr := backend.Accept()
+			if acceptErr != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	_, backendPort, _ := net.SplitHostPort(backend.Addr().String())
+	port, _ := strconv.Atoi(backendPort)
+
+	scheme := runtime.NewScheme()
+	if err = clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+	tcp.Spec.NetworkProfile.Port = int32(port)
+	tcp.Spec.ControlPlane.IdlePolicy = &kamajiv1alpha1.IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}}
+	tcp.Status.Conditions = []metav1.Condition{{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached}}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00-abcde", Labels: map[string]string{"kamaji.clastix.io/name": "tenant-00"}},
+		Status: corev1.PodStatus{
+			PodIP:      "127.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp, pod).Build()
+
+	activator := &Activator{Client: c, WakeUpTimeout: 10 * time.Second, DialTimeout: time.Second, Log: logr.Discard()}
+	defer activator.Close(k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"})
+
+	listenerPort, err := activator.Listen(k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(listenerPort))), time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	if _, err = fmt.Fprint(conn, "ping"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	reply := make([]byte, 4)
+	if _, err = io.ReadFull(conn, reply); err != nil {
+		t.Fatalf("expected the connection to be forwarded to the API Server: %s", err)
+	}
+
+	if string(reply) != "ping" {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+
+	if err = c.Get(context.Background(), k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"}, tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	if condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeIdle); condition == nil || condition.Reason != kamajiv1alpha1.IdleReasonWakingUp {
+		t.Fatalf("expected the Tenant Control Plane to be waking up, got %+v", condition)
+	}
+}
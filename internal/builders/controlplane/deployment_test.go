@@ -0,0 +1,184 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func TestDeploymentSetReplicas(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition *metav1.Condition
+		expected  int32
+	}{
+		{name: "active", expected: 3},
+		{
+			name:      "scaled to zero",
+			condition: &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionTrue, Reason: kamajiv1alpha1.IdleReasonInactivityTimeoutReached},
+			expected:  0,
+		},
+		{
+			name:      "waking up",
+			condition: &metav1.Condition{Type: kamajiv1alpha1.ConditionTypeIdle, Status: metav1.ConditionFalse, Reason: kamajiv1alpha1.IdleReasonWakingUp},
+			expected:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.ControlPlane.Deployment.Replicas = pointer.Int32(3)
+			tcp.Spec.ControlPlane.IdlePolicy = &kamajiv1alpha1.IdlePolicySpec{IdleTimeout: metav1.Duration{Duration: time.Hour}}
+
+			if tt.condition != nil {
+				tcp.Status.Conditions = []metav1.Condition{*tt.condition}
+			}
+
+			spec := &appsv1.DeploymentSpec{}
+			Deployment{}.setReplicas(spec, tcp)
+
+			if spec.Replicas == nil || *spec.Replicas != tt.expected {
+				t.Fatalf("expected %d replicas, got %v", tt.expected, spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestDeploymentInflightArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		flowControl bool
+		cpu         string
+		extraArgs   []string
+		current     map[string]string
+		expected    map[string]string
+	}{
+		{name: "flow control disabled", cpu: "2", current: map[string]string{"--max-requests-inflight": "400"}, expected: map[string]string{}},
+		{name: "no cpu requests", flowControl: true, expected: map[string]string{}},
+		{name: "sized", flowControl: true, cpu: "2", expected: map[string]string{"--max-requests-inflight": "400", "--max-mutating-requests-inflight": "200"}},
+		{name: "lower bounds", flowControl: true, cpu: "100m", expected: map[string]string{"--max-requests-inflight": "50", "--max-mutating-requests-inflight": "25"}},
+		{
+			name:        "extra args override",
+			flowControl: true,
+			cpu:         "2",
+			extraArgs:   []string{"--max-requests-inflight=1000"},
+			expected:    map[string]string{"--max-requests-inflight": "1000", "--max-mutating-requests-inflight": "200"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{APIServer: tt.extraArgs}
+
+			if tt.flowControl {
+				tcp.Spec.Addons.FlowControl = &kamajiv1alpha1.FlowControlSpec{}
+			}
+
+			if tt.cpu != "" {
+				tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{
+					APIServer: &corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(tt.cpu)}},
+				}
+			}
+
+			current := map[string]string{}
+			for k, v := range tt.current {
+				current[k] = v
+			}
+
+			args := Deployment{}.buildKubeAPIServerCommand(tcp, "127.0.0.1", current)
+
+			for _, flag := range []string{"--max-requests-inflight", "--max-mutating-requests-inflight"} {
+				if args[flag] != tt.expected[flag] {
+					t.Fatalf("expected %s to be %q, got %q", flag, tt.expected[flag], args[flag])
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentLeaderElectionArgs(t *testing.T) {
+	args := map[string]string{"--leader-elect-lease-duration": "30s"}
+
+	Deployment{}.setLeaderElectionArgs(args, nil)
+
+	if len(args) != 1 {
+		t.Fatalf("expected the arguments to be untouched, got %v", args)
+	}
+
+	Deployment{}.setLeaderElectionArgs(args, &kamajiv1alpha1.LeaderElectionSpec{
+		LeaseDuration: &metav1.Duration{Duration: time.Minute},
+		RetryPeriod:   &metav1.Duration{Duration: 5 * time.Second},
+	})
+
+	expected := map[string]string{"--leader-elect-lease-duration": "1m0s", "--leader-elect-retry-period": "5s"}
+
+	for flag, value := range expected {
+		if args[flag] != value {
+			t.Fatalf("expected %s to be %q, got %q", flag, value, args[flag])
+		}
+	}
+
+	if _, ok := args["--leader-elect-renew-deadline"]; ok {
+		t.Fatal("expected the renew deadline to be left to the component default")
+	}
+}
+
+func TestDeploymentKineEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		postgreSQL *kamajiv1alpha1.PostgreSQLOptions
+		expected   string
+	}{
+		{
+			name:     "database isolation by default",
+			expected: "--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/$(DB_SCHEMA)",
+		},
+		{
+			name:       "database isolation",
+			postgreSQL: &kamajiv1alpha1.PostgreSQLOptions{Isolation: kamajiv1alpha1.PostgreSQLDatabaseIsolation, SharedDatabase: "kamaji"},
+			expected:   "--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/$(DB_SCHEMA)",
+		},
+		{
+			name:       "schema isolation",
+			postgreSQL: &kamajiv1alpha1.PostgreSQLOptions{Isolation: kamajiv1alpha1.PostgreSQLSchemaIsolation, SharedDatabase: "kamaji"},
+			expected:   "--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/kamaji?search_path=$(DB_SCHEMA)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Deployment{}
+			d.DataStore.Spec.Driver = kamajiv1alpha1.KinePostgreSQLDriver
+			d.DataStore.Spec.PostgreSQL = tt.postgreSQL
+
+			podSpec := &corev1.PodSpec{}
+			d.buildKine(podSpec, kamajiv1alpha1.TenantControlPlane{})
+
+			found, index := utilities.HasNamedContainer(podSpec.Containers, kineContainerName)
+			if !found {
+				t.Fatal("expected the kine container")
+			}
+
+			for _, arg := range podSpec.Containers[index].Args {
+				if arg == tt.expected {
+					return
+				}
+			}
+
+			t.Fatalf("expected the %q argument, got %v", tt.expected, podSpec.Containers[index].Args)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Scheduling contains the operator-level scheduling defaults of the Tenant Control Plane pods:
+// these are applied only when the Tenant Control Plane is not declaring its own affinity, or topology spread constraints.
+// All the constraints are soft ones, allowing the scheduling of the pods even if these cannot be satisfied.
+type Scheduling struct {
+	// ZoneSpread spreads the replicas of a Tenant Control Plane across the zones.
+	ZoneSpread bool
+	// HostnameSpread spreads the replicas of a Tenant Control Plane across the nodes.
+	HostnameSpread bool
+	// AntiAffinity prefers to schedule the replicas of a Tenant Control Plane on different nodes.
+	AntiAffinity bool
+	// DataStoreSpread prefers to schedule the Tenant Control Planes sharing the same DataStore in different zones,
+	// limiting the blast radius of a zone outage for the tenants using it.
+	DataStoreSpread bool
+}
+
+const (
+	dataStoreTemplateLabel = "component.kamaji.clastix.io/datastore"
+	nameTemplateLabel      = "kamaji.clastix.io/name"
+)
+
+func (s Scheduling) topologySpreadConstraints() []corev1.TopologySpreadConstraint {
+	var constraints []corev1.TopologySpreadConstraint
+	// The label selector is left empty, defaulted to the Deployment one.
+	if s.ZoneSpread {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		})
+	}
+
+	if s.HostnameSpread {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelHostname,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		})
+	}
+
+	return constraints
+}
+
+func (s Scheduling) affinity(tcp kamajiv1alpha1.TenantControlPlane) *corev1.Affinity {
+	var terms []corev1.WeightedPodAffinityTerm
+
+	if s.AntiAffinity {
+		terms = append(terms, corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						nameTemplateLabel: tcp.GetName(),
+					},
+				},
+				TopologyKey: corev1.LabelHostname,
+			},
+		})
+	}
+
+	if s.DataStoreSpread && len(tcp.Spec.DataStore) > 0 {
+		terms = append(terms, corev1.WeightedPodAffinityTerm{
+			Weight: 50,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						dataStoreTemplateLabel: tcp.Spec.DataStore,
+					},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      nameTemplateLabel,
+							Operator: metav1.LabelSelectorOpNotIn,
+							Values:   []string{tcp.GetName()},
+						},
+					},
+				},
+				// The Tenant Control Planes sharing the same DataStore can be placed in any namespace.
+				NamespaceSelector: &metav1.LabelSelector{},
+				TopologyKey:       corev1.LabelTopologyZone,
+			},
+		})
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: terms,
+		},
+	}
+}
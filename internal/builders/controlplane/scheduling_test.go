@@ -0,0 +1,75 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDeploymentSchedulingDefaults(t *testing.T) {
+	d := Deployment{Scheduling: Scheduling{ZoneSpread: true, HostnameSpread: true, AntiAffinity: true, DataStoreSpread: true}}
+
+	tcp := kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+	tcp.Spec.DataStore = "default"
+
+	spec := &appsv1.DeploymentSpec{}
+	d.setSelector(spec, tcp)
+	d.setTopologySpreadConstraints(spec, d.topologySpreadConstraints(tcp))
+	d.setAffinity(&spec.Template.Spec, tcp)
+
+	constraints := spec.Template.Spec.TopologySpreadConstraints
+	if len(constraints) != 2 || constraints[0].TopologyKey != corev1.LabelTopologyZone || constraints[1].TopologyKey != corev1.LabelHostname {
+		t.Fatalf("expected the zone and hostname spread constraints, got %v", constraints)
+	}
+
+	for _, constraint := range constraints {
+		if constraint.LabelSelector != spec.Selector {
+			t.Fatalf("expected the constraint to select the Deployment pods, got %v", constraint.LabelSelector)
+		}
+	}
+
+	affinity := spec.Template.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil || len(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 2 {
+		t.Fatalf("expected the tenant and DataStore anti-affinity terms, got %v", affinity)
+	}
+
+	dataStoreTerm := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[1].PodAffinityTerm
+	if dataStoreTerm.LabelSelector.MatchLabels[dataStoreTemplateLabel] != "default" || dataStoreTerm.NamespaceSelector == nil {
+		t.Fatalf("expected the DataStore term to select the pods of any namespace using the same DataStore, got %v", dataStoreTerm)
+	}
+}
+
+func TestDeploymentSchedulingOverrides(t *testing.T) {
+	d := Deployment{Scheduling: Scheduling{ZoneSpread: true, AntiAffinity: true}}
+
+	tcp := kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+	tcp.Spec.ControlPlane.Deployment.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}
+	tcp.Spec.ControlPlane.Deployment.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: "rack"}}
+
+	spec := &appsv1.DeploymentSpec{}
+	d.setSelector(spec, tcp)
+	d.setTopologySpreadConstraints(spec, d.topologySpreadConstraints(tcp))
+	d.setAffinity(&spec.Template.Spec, tcp)
+
+	if constraints := spec.Template.Spec.TopologySpreadConstraints; len(constraints) != 1 || constraints[0].TopologyKey != "rack" {
+		t.Fatalf("expected the declared topology spread constraints, got %v", constraints)
+	}
+
+	if affinity := spec.Template.Spec.Affinity; affinity.PodAntiAffinity != nil || affinity.NodeAffinity == nil {
+		t.Fatalf("expected the declared affinity, got %v", affinity)
+	}
+
+	d.Scheduling = Scheduling{}
+	tcp.Spec.ControlPlane.Deployment.Affinity = nil
+
+	if d.setAffinity(&spec.Template.Spec, tcp); spec.Template.Spec.Affinity != nil {
+		t.Fatalf("expected no affinity without defaults, got %v", spec.Template.Spec.Affinity)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// defaultReplicas is the number of Tenant Control Plane Pods when neither the Tenant Control Plane,
+// nor the referred ControlPlaneSize, are declaring it.
+const defaultReplicas = 2
+
+// WithSize returns the Tenant Control Plane with the given ControlPlaneSize applied to the Deployment specification:
+// the replicas, and the resources, declared in the Tenant Control Plane take precedence, as well as the extra arguments
+// with the same flag.
+func WithSize(tcp kamajiv1alpha1.TenantControlPlane, size *kamajiv1alpha1.ControlPlaneSize) kamajiv1alpha1.TenantControlPlane {
+	deployment := tcp.Spec.ControlPlane.Deployment.DeepCopy()
+
+	if size != nil {
+		if deployment.Replicas == nil && size.Spec.Replicas != nil {
+			deployment.Replicas = pointer.Int32(*size.Spec.Replicas)
+		}
+
+		if preset := size.Spec.Resources; preset != nil {
+			if deployment.Resources == nil {
+				deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{}
+			}
+
+			deployment.Resources.APIServer = withResources(deployment.Resources.APIServer, preset.APIServer)
+			deployment.Resources.ControllerManager = withResources(deployment.Resources.ControllerManager, preset.ControllerManager)
+			deployment.Resources.Scheduler = withResources(deployment.Resources.Scheduler, preset.Scheduler)
+			deployment.Resources.Kine = withResources(deployment.Resources.Kine, preset.Kine)
+		}
+
+		if preset := size.Spec.ExtraArgs; preset != nil {
+			if deployment.ExtraArgs == nil {
+				deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{}
+			}
+			// The arguments are converted to a map by the builder, the last occurrence of a flag wins.
+			deployment.ExtraArgs.APIServer = append(append([]string{}, preset.APIServer...), deployment.ExtraArgs.APIServer...)
+			deployment.ExtraArgs.ControllerManager = append(append([]string{}, preset.ControllerManager...), deployment.ExtraArgs.ControllerManager...)
+			deployment.ExtraArgs.Scheduler = append(append([]string{}, preset.Scheduler...), deployment.ExtraArgs.Scheduler...)
+			deployment.ExtraArgs.Kine = append(append([]string{}, preset.Kine...), deployment.ExtraArgs.Kine...)
+		}
+	}
+
+	if deployment.Replicas == nil {
+		deployment.Replicas = pointer.Int32(defaultReplicas)
+	}
+
+	tcp.Spec.ControlPlane.Deployment = *deployment
+
+	return tcp
+}
+
+func withResources(declared, preset *corev1.ResourceRequirements) *corev1.ResourceRequirements {
+	if declared != nil {
+		return declared
+	}
+
+	return preset.DeepCopy()
+}
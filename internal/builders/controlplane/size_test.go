@@ -0,0 +1,91 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func sizeTestResources(cpu string) *corev1.ResourceRequirements {
+	return &corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+}
+
+func TestWithSize(t *testing.T) {
+	size := &kamajiv1alpha1.ControlPlaneSize{
+		Spec: kamajiv1alpha1.ControlPlaneSizeSpec{
+			Replicas: pointer.Int32(3),
+			Resources: &kamajiv1alpha1.ControlPlaneComponentsResources{
+				APIServer: sizeTestResources("1"),
+				Scheduler: sizeTestResources("100m"),
+			},
+			ExtraArgs: &kamajiv1alpha1.ControlPlaneExtraArgs{
+				APIServer: []string{"--max-requests-inflight=800", "--default-watch-cache-size=200"},
+				Kine:      []string{"--compact-interval=1m"},
+			},
+		},
+	}
+
+	t.Run("preset", func(t *testing.T) {
+		tcp := WithSize(kamajiv1alpha1.TenantControlPlane{}, size)
+		deployment := tcp.Spec.ControlPlane.Deployment
+
+		if *deployment.Replicas != 3 {
+			t.Fatalf("expected the preset replicas, got %d", *deployment.Replicas)
+		}
+
+		if !reflect.DeepEqual(deployment.Resources.APIServer, size.Spec.Resources.APIServer) || deployment.Resources.ControllerManager != nil {
+			t.Fatalf("unexpected resources %+v", deployment.Resources)
+		}
+
+		if !reflect.DeepEqual(deployment.ExtraArgs.Kine, size.Spec.ExtraArgs.Kine) {
+			t.Fatalf("unexpected kine args %v", deployment.ExtraArgs.Kine)
+		}
+	})
+
+	t.Run("explicit values override the preset", func(t *testing.T) {
+		tcp := kamajiv1alpha1.TenantControlPlane{}
+		tcp.Spec.ControlPlane.Deployment.Replicas = pointer.Int32(1)
+		tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{APIServer: sizeTestResources("2")}
+		tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{APIServer: []string{"--max-requests-inflight=1600"}}
+
+		deployment := WithSize(tcp, size).Spec.ControlPlane.Deployment
+
+		if *deployment.Replicas != 1 {
+			t.Fatalf("expected the declared replicas, got %d", *deployment.Replicas)
+		}
+
+		if cpu := deployment.Resources.APIServer.Requests[corev1.ResourceCPU]; cpu.String() != "2" {
+			t.Fatalf("expected the declared API Server resources, got %s", cpu.String())
+		}
+
+		if !reflect.DeepEqual(deployment.Resources.Scheduler, size.Spec.Resources.Scheduler) {
+			t.Fatalf("expected the preset scheduler resources, got %+v", deployment.Resources.Scheduler)
+		}
+
+		args := utilities.ArgsFromSliceToMap(deployment.ExtraArgs.APIServer)
+		if args["--max-requests-inflight"] != "1600" || args["--default-watch-cache-size"] != "200" {
+			t.Fatalf("unexpected API Server args %v", args)
+		}
+		// The Tenant Control Plane specification must not be affected.
+		if len(tcp.Spec.ControlPlane.Deployment.ExtraArgs.APIServer) != 1 || tcp.Spec.ControlPlane.Deployment.Resources.Scheduler != nil {
+			t.Fatalf("the Tenant Control Plane specification has been modified")
+		}
+	})
+
+	t.Run("no size", func(t *testing.T) {
+		deployment := WithSize(kamajiv1alpha1.TenantControlPlane{}, nil).Spec.ControlPlane.Deployment
+
+		if *deployment.Replicas != defaultReplicas || deployment.Resources != nil || deployment.ExtraArgs != nil {
+			t.Fatalf("unexpected Deployment specification %+v", deployment)
+		}
+	})
+}
@@ -7,4 +7,22 @@ const (
 	// Checksum is the annotation label that we use to store the checksum for the resource:
 	// it allows to check by comparing it if the resource has been changed and must be aligned with the reconciliation.
 	Checksum = "kamaji.clastix.io/checksum"
+	// AdditionalLabels and AdditionalAnnotations are the annotations tracking the keys of the additional metadata
+	// applied to the resource: they allow removing the ones no more declared by the Tenant Control Plane.
+	AdditionalLabels      = "kamaji.clastix.io/additional-labels"
+	AdditionalAnnotations = "kamaji.clastix.io/additional-annotations"
+	// ReservedPrefix is the prefix of the labels and annotations managed by Kamaji,
+	// which cannot be overridden using the additional metadata.
+	ReservedPrefix = "kamaji.clastix.io/"
+	// Priority is the annotation used to assign the Tenant Control Plane a priority class,
+	// honored by the controllers when dispatching the reconciliation requests.
+	Priority = "kamaji.clastix.io/priority"
+	// ImageDigests is the annotation tracking the digests the Tenant Control Plane images have been pinned to
+	// upon admission, as a JSON map of the image references: removing it resolves the digests again.
+	ImageDigests = "kamaji.clastix.io/image-digests"
+	// JoinArtifacts is the annotation used to request the worker nodes join artifacts of a Tenant Control Plane:
+	// changing its value renders them again, along with a new bootstrap token.
+	JoinArtifacts = "kamaji.clastix.io/join-artifacts"
+	// JoinTokenExpiration is the annotation reporting the expiration of the bootstrap token of the join artifacts.
+	JoinTokenExpiration = "kamaji.clastix.io/join-token-expiration"
 )
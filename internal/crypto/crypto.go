@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"math/big"
 	mathrand "math/rand"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -135,6 +136,48 @@ func IsValidCertificateKeyPairBytes(certificateBytes []byte, privateKeyBytes []b
 	}
 }
 
+// CheckCertificateSANs checks if the certificate is valid for all the given DNS names and IP addresses.
+func CheckCertificateSANs(certificateBytes []byte, dnsNames []string, ips []net.IP) (bool, error) {
+	crt, err := ParseCertificateBytes(certificateBytes)
+	if err != nil {
+		return false, err
+	}
+
+	for _, dnsName := range dnsNames {
+		found := false
+
+		for _, name := range crt.DNSNames {
+			if name == dnsName {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	for _, ip := range ips {
+		found := false
+
+		for _, address := range crt.IPAddresses {
+			if address.Equal(ip) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error) {
 	if len(usages) == 0 {
 		return false, fmt.Errorf("missing usages for certificate verification")
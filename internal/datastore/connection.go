@@ -30,7 +30,7 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 	case kamajiv1alpha1.KinePostgreSQLDriver:
 		cc.TLSConfig.ServerName = cc.Endpoints[0].Host
 		//nolint:contextcheck
-		return NewPostgreSQLConnection(*cc)
+		return NewPostgreSQLConnection(*cc, ds.SharedDatabase())
 	case kamajiv1alpha1.EtcdDriver:
 		return NewETCDConnection(*cc)
 	default:
@@ -39,10 +39,13 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 }
 
 type Connection interface {
-	CreateUser(ctx context.Context, user, password string) error
+	// CreateUser creates the user, or updates the existing one, enforcing the given policy.
+	CreateUser(ctx context.Context, user, password string, policy kamajiv1alpha1.DataStoreUserPolicy) error
 	CreateDB(ctx context.Context, dbName string) error
 	GrantPrivileges(ctx context.Context, user, dbName string) error
-	UserExists(ctx context.Context, user string) (bool, error)
+	// UserExists checks if the user exists: when the policy is not nil,
+	// the user is reported as missing if the policy is not enforced yet.
+	UserExists(ctx context.Context, user string, policy *kamajiv1alpha1.DataStoreUserPolicy) (bool, error)
 	DBExists(ctx context.Context, dbName string) (bool, error)
 	GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error)
 	DeleteUser(ctx context.Context, user string) error
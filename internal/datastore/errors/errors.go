@@ -48,3 +48,23 @@ func NewCheckConnectionError(err error) error {
 func NewCreateDBError(err error) error {
 	return errors.Wrap(err, "cannot create database")
 }
+
+func NewMemberStatusError(err error) error {
+	return errors.Wrap(err, "cannot retrieve member status")
+}
+
+func NewListAlarmsError(err error) error {
+	return errors.Wrap(err, "cannot list alarms")
+}
+
+func NewCompactError(err error) error {
+	return errors.Wrap(err, "cannot compact keyspace")
+}
+
+func NewDefragmentError(err error) error {
+	return errors.Wrap(err, "cannot defragment member")
+}
+
+func NewDisarmAlarmError(err error) error {
+	return errors.Wrap(err, "cannot disarm alarm")
+}
@@ -9,6 +9,7 @@ import (
 
 	goerrors "github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/authpb"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	etcdclient "go.etcd.io/etcd/client/v3"
 
@@ -45,11 +46,24 @@ func NewETCDConnection(config ConnectionConfig) (Connection, error) {
 	}, nil
 }
 
+// EtcdNoSpaceAlarm is the alarm raised by the members exceeding their database quota,
+// turning the cluster read-only until it's disarmed.
+var EtcdNoSpaceAlarm = etcdserverpb.AlarmType_NOSPACE.String()
+
 type EtcdClient struct {
 	Client etcdclient.Client
 }
 
-func (e *EtcdClient) CreateUser(ctx context.Context, user, password string) error {
+// EtcdMemberStatus is the status of the etcd member served by an endpoint.
+type EtcdMemberStatus struct {
+	MemberID    uint64
+	Revision    int64
+	DBSize      int64
+	DBSizeInUse int64
+	IsLeader    bool
+}
+
+func (e *EtcdClient) CreateUser(ctx context.Context, user, password string, _ kamajiv1alpha1.DataStoreUserPolicy) error {
 	if _, err := e.Client.Auth.UserAddWithOptions(ctx, user, password, &etcdclient.UserAddOptions{NoPassword: true}); err != nil {
 		return errors.NewCreateUserError(err)
 	}
@@ -79,7 +93,7 @@ func (e *EtcdClient) GrantPrivileges(ctx context.Context, user, dbName string) e
 	return nil
 }
 
-func (e *EtcdClient) UserExists(ctx context.Context, user string) (bool, error) {
+func (e *EtcdClient) UserExists(ctx context.Context, user string, _ *kamajiv1alpha1.DataStoreUserPolicy) (bool, error) {
 	if _, err := e.Client.UserGet(ctx, user); err != nil {
 		if goerrors.As(err, &rpctypes.ErrGRPCUserNotFound) {
 			return false, nil
@@ -194,3 +208,68 @@ func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantContr
 
 	return nil
 }
+
+// Endpoints returns the endpoints of the etcd members.
+func (e *EtcdClient) Endpoints() []string {
+	return e.Client.Endpoints()
+}
+
+// MemberStatus returns the status of the member served by the given endpoint.
+func (e *EtcdClient) MemberStatus(ctx context.Context, endpoint string) (*EtcdMemberStatus, error) {
+	response, err := e.Client.Status(ctx, endpoint)
+	if err != nil {
+		return nil, errors.NewMemberStatusError(err)
+	}
+
+	return &EtcdMemberStatus{
+		MemberID:    response.Header.MemberId,
+		Revision:    response.Header.Revision,
+		DBSize:      response.DbSize,
+		DBSizeInUse: response.DbSizeInUse,
+		IsLeader:    response.Leader == response.Header.MemberId,
+	}, nil
+}
+
+// Alarms returns the alarms raised by the members, by member ID.
+func (e *EtcdClient) Alarms(ctx context.Context) (map[uint64][]string, error) {
+	response, err := e.Client.AlarmList(ctx)
+	if err != nil {
+		return nil, errors.NewListAlarmsError(err)
+	}
+
+	alarms := make(map[uint64][]string, len(response.Alarms))
+	for _, alarm := range response.Alarms {
+		alarms[alarm.MemberID] = append(alarms[alarm.MemberID], alarm.Alarm.String())
+	}
+
+	return alarms, nil
+}
+
+// Compact compacts the keyspace up to the given revision, physically removing the compacted keys:
+// a revision already compacted is ignored.
+func (e *EtcdClient) Compact(ctx context.Context, revision int64) error {
+	if _, err := e.Client.Compact(ctx, revision, etcdclient.WithCompactPhysical()); err != nil && !goerrors.Is(err, rpctypes.ErrCompacted) {
+		return errors.NewCompactError(err)
+	}
+
+	return nil
+}
+
+// Defragment defragments the database of the member served by the given endpoint,
+// which cannot serve any request until the defragmentation is completed.
+func (e *EtcdClient) Defragment(ctx context.Context, endpoint string) error {
+	if _, err := e.Client.Defragment(ctx, endpoint); err != nil {
+		return errors.NewDefragmentError(err)
+	}
+
+	return nil
+}
+
+// DisarmNoSpaceAlarm disarms the NOSPACE alarm raised by the given member.
+func (e *EtcdClient) DisarmNoSpaceAlarm(ctx context.Context, memberID uint64) error {
+	if _, err := e.Client.AlarmDisarm(ctx, &etcdclient.AlarmMember{MemberID: memberID, Alarm: etcdserverpb.AlarmType_NOSPACE}); err != nil {
+		return errors.NewDisarmAlarmError(err)
+	}
+
+	return nil
+}
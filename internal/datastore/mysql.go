@@ -7,12 +7,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/JamesStewy/go-mysqldump"
 	"github.com/go-sql-driver/mysql"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/datastore/errors"
@@ -24,15 +27,17 @@ const (
 )
 
 const (
-	mysqlFetchUserStatement        = "SELECT User FROM mysql.user WHERE User= ? LIMIT 1"
-	mysqlFetchDBStatement          = "SELECT SCHEMA_NAME FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME=? LIMIT 1"
-	mysqlShowGrantsStatement       = "SHOW GRANTS FOR `%s`@`%%`"
-	mysqlCreateDBStatement         = "CREATE DATABASE IF NOT EXISTS %s"
-	mysqlCreateUserStatement       = "CREATE USER `%s`@`%%` IDENTIFIED BY '%s'"
-	mysqlGrantPrivilegesStatement  = "GRANT ALL PRIVILEGES ON `%s`.* TO `%s`@`%%`"
-	mysqlDropDBStatement           = "DROP DATABASE IF EXISTS `%s`"
-	mysqlDropUserStatement         = "DROP USER IF EXISTS `%s`"
-	mysqlRevokePrivilegesStatement = "REVOKE ALL PRIVILEGES ON `%s`.* FROM `%s`"
+	mysqlFetchUserStatement         = "SELECT User FROM mysql.user WHERE User= ? LIMIT 1"
+	mysqlFetchUserAccountsStatement = "SELECT Host, max_user_connections FROM mysql.user WHERE User= ?"
+	mysqlFetchDBStatement           = "SELECT SCHEMA_NAME FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME=? LIMIT 1"
+	mysqlShowGrantsStatement        = "SHOW GRANTS FOR `%s`@`%s`"
+	mysqlCreateDBStatement          = "CREATE DATABASE IF NOT EXISTS %s"
+	mysqlCreateUserStatement        = "CREATE USER IF NOT EXISTS `%s`@`%s` IDENTIFIED BY '%s'"
+	mysqlAlterUserStatement         = "ALTER USER `%s`@`%s` WITH MAX_USER_CONNECTIONS %d"
+	mysqlGrantPrivilegesStatement   = "GRANT ALL PRIVILEGES ON `%s`.* TO `%s`@`%s`"
+	mysqlDropDBStatement            = "DROP DATABASE IF EXISTS `%s`"
+	mysqlDropUserStatement          = "DROP USER IF EXISTS `%s`@`%s`"
+	mysqlRevokePrivilegesStatement  = "REVOKE ALL PRIVILEGES ON `%s`.* FROM `%s`@`%s`"
 )
 
 type MySQLConnection struct {
@@ -148,11 +153,35 @@ func (c *MySQLConnection) Check(ctx context.Context) error {
 	return nil
 }
 
-func (c *MySQLConnection) CreateUser(ctx context.Context, user, password string) error {
-	if err := c.mutate(ctx, mysqlCreateUserStatement, user, password); err != nil {
+// CreateUser creates a user account for each allowed host, removing the ones of the hosts no more allowed.
+func (c *MySQLConnection) CreateUser(ctx context.Context, user, password string, policy kamajiv1alpha1.DataStoreUserPolicy) error {
+	hosts := mysqlHosts(policy.AllowedHosts)
+
+	for _, host := range hosts.List() {
+		if err := c.mutate(ctx, mysqlCreateUserStatement, user, host, password); err != nil {
+			return errors.NewCreateUserError(err)
+		}
+
+		if err := c.mutate(ctx, mysqlAlterUserStatement, user, host, mysqlMaxConnections(policy)); err != nil {
+			return errors.NewCreateUserError(err)
+		}
+	}
+
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
 		return errors.NewCreateUserError(err)
 	}
 
+	for host := range accounts {
+		if hosts.Has(host) {
+			continue
+		}
+
+		if err = c.mutate(ctx, mysqlDropUserStatement, user, host); err != nil {
+			return errors.NewCreateUserError(err)
+		}
+	}
+
 	return nil
 }
 
@@ -165,14 +194,25 @@ func (c *MySQLConnection) CreateDB(ctx context.Context, dbName string) error {
 }
 
 func (c *MySQLConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
-	if err := c.mutate(ctx, mysqlGrantPrivilegesStatement, user, dbName); err != nil {
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
 
+	for host := range accounts {
+		if err = c.mutate(ctx, mysqlGrantPrivilegesStatement, dbName, user, host); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
 	return nil
 }
 
-func (c *MySQLConnection) UserExists(ctx context.Context, user string) (bool, error) {
+func (c *MySQLConnection) UserExists(ctx context.Context, user string, policy *kamajiv1alpha1.DataStoreUserPolicy) (bool, error) {
+	if policy != nil {
+		return c.userPolicyExists(ctx, user, *policy)
+	}
+
 	checker := func(row *sql.Row) (bool, error) {
 		var name string
 		if err := row.Scan(&name); err != nil {
@@ -194,6 +234,29 @@ func (c *MySQLConnection) UserExists(ctx context.Context, user string) (bool, er
 	return ok, nil
 }
 
+// userPolicyExists checks if the user has an account for each allowed host, and no other ones,
+// along with the expected connections limit.
+func (c *MySQLConnection) userPolicyExists(ctx context.Context, user string, policy kamajiv1alpha1.DataStoreUserPolicy) (bool, error) {
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
+		return false, errors.NewCheckUserExistsError(err)
+	}
+
+	hosts := mysqlHosts(policy.AllowedHosts)
+	if len(accounts) != hosts.Len() {
+		return false, nil
+	}
+
+	for _, host := range hosts.List() {
+		maxConnections, ok := accounts[host]
+		if !ok || maxConnections != mysqlMaxConnections(policy) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func (c *MySQLConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
 	checker := func(row *sql.Row) (bool, error) {
 		var name string
@@ -216,14 +279,39 @@ func (c *MySQLConnection) DBExists(ctx context.Context, dbName string) (bool, er
 	return ok, nil
 }
 
+// GrantPrivilegesExists checks if all the user accounts have been granted the privileges on the database.
 func (c *MySQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
-	statementShowGrantsStatement := fmt.Sprintf(mysqlShowGrantsStatement, user)
-	rows, err := c.db.Query(statementShowGrantsStatement)
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	if len(accounts) == 0 {
+		return false, nil
+	}
+
+	for host := range accounts {
+		ok, grantErr := c.grantExists(ctx, user, host, dbName)
+		if grantErr != nil {
+			return false, grantErr
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (c *MySQLConnection) grantExists(ctx context.Context, user, host, dbName string) (bool, error) {
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(mysqlShowGrantsStatement, user, host))
 	if err != nil {
 		return false, errors.NewGrantPrivilegesError(err)
 	}
+	defer rows.Close()
 
-	expected := fmt.Sprintf(mysqlGrantPrivilegesStatement, user, dbName)
+	expected := fmt.Sprintf(mysqlGrantPrivilegesStatement, dbName, user, host)
 	var grant string
 
 	for rows.Next() {
@@ -240,10 +328,17 @@ func (c *MySQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbNam
 }
 
 func (c *MySQLConnection) DeleteUser(ctx context.Context, user string) error {
-	if err := c.mutate(ctx, mysqlDropUserStatement, user); err != nil {
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
 		return errors.NewDeleteUserError(err)
 	}
 
+	for host := range accounts {
+		if err = c.mutate(ctx, mysqlDropUserStatement, user, host); err != nil {
+			return errors.NewDeleteUserError(err)
+		}
+	}
+
 	return nil
 }
 
@@ -256,13 +351,54 @@ func (c *MySQLConnection) DeleteDB(ctx context.Context, dbName string) error {
 }
 
 func (c *MySQLConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
-	if err := c.mutate(ctx, mysqlRevokePrivilegesStatement, user, dbName); err != nil {
+	accounts, err := c.userAccounts(ctx, user)
+	if err != nil {
 		return errors.NewRevokePrivilegesError(err)
 	}
 
+	for host := range accounts {
+		// Revoking privileges never granted is failing.
+		ok, grantErr := c.grantExists(ctx, user, host, dbName)
+		if grantErr != nil {
+			return errors.NewRevokePrivilegesError(grantErr)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err = c.mutate(ctx, mysqlRevokePrivilegesStatement, dbName, user, host); err != nil {
+			return errors.NewRevokePrivilegesError(err)
+		}
+	}
+
 	return nil
 }
 
+// userAccounts returns the hosts of the accounts of the given user, along with their connections limit.
+func (c *MySQLConnection) userAccounts(ctx context.Context, user string) (map[string]int64, error) {
+	rows, err := c.db.QueryContext(ctx, mysqlFetchUserAccountsStatement, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make(map[string]int64)
+
+	for rows.Next() {
+		var host string
+		var maxConnections int64
+
+		if err = rows.Scan(&host, &maxConnections); err != nil {
+			return nil, err
+		}
+
+		accounts[host] = maxConnections
+	}
+
+	return accounts, rows.Err()
+}
+
 func (c *MySQLConnection) check(ctx context.Context, nonFilledStatement string, checker func(*sql.Row) (bool, error), args ...any) (bool, error) {
 	statement, err := c.db.Prepare(nonFilledStatement)
 	if err != nil {
@@ -287,3 +423,32 @@ func (c *MySQLConnection) mutate(ctx context.Context, nonFilledStatement string,
 func (c *MySQLConnection) checkEmptyQueryResult(err error) bool {
 	return err.Error() == sqlErrorNoRows
 }
+
+// mysqlHosts returns the hosts of the user accounts, converting the IPv4 CIDRs to the netmask notation
+// supported by both MySQL and MariaDB: when no host is allowed, the user can connect from any host.
+func mysqlHosts(allowedHosts []string) sets.String {
+	hosts := sets.NewString()
+
+	for _, host := range allowedHosts {
+		if _, network, err := net.ParseCIDR(host); err == nil && network.IP.To4() != nil {
+			host = fmt.Sprintf("%s/%s", network.IP.String(), net.IP(network.Mask).String())
+		}
+		// The host names are stored in lowercase.
+		hosts.Insert(strings.ToLower(host))
+	}
+
+	if hosts.Len() == 0 {
+		hosts.Insert("%")
+	}
+
+	return hosts
+}
+
+// mysqlMaxConnections returns the MAX_USER_CONNECTIONS value of the given policy, where zero means no limit.
+func mysqlMaxConnections(policy kamajiv1alpha1.DataStoreUserPolicy) int64 {
+	if policy.MaxConnections == nil {
+		return 0
+	}
+
+	return int64(*policy.MaxConnections)
+}
@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-pg/pg/v10"
+	goerrors "github.com/pkg/errors"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/datastore/errors"
@@ -29,12 +31,34 @@ const (
 	postgresqlRevokePrivilegesStatement   = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s"
 	postgresqlDropRoleStatement           = "DROP ROLE %s"
 	postgresqlDropDBStatement             = "DROP DATABASE %s WITH (FORCE)"
+	// The following statements are used with the Schema isolation, where each tenant has its own schema in the shared database.
+	postgresqlFetchSchemaStatement              = "SELECT FROM pg_namespace WHERE nspname = ?"
+	postgresqlCreateSchemaStatement             = "CREATE SCHEMA %s"
+	postgresqlDropSchemaStatement               = "DROP SCHEMA %s CASCADE"
+	postgresqlRevokePublicStatement             = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM PUBLIC"
+	postgresqlRevokePublicSchemaStatement       = "REVOKE CREATE ON SCHEMA public FROM PUBLIC"
+	postgresqlShowConnectGrantsStatement        = "SELECT has_database_privilege(rolname, ?, 'connect') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlShowSchemaOwnershipStatement      = "SELECT 't' FROM pg_catalog.pg_namespace AS n WHERE n.nspname = ? AND pg_catalog.pg_get_userbyid(n.nspowner) = ?"
+	postgresqlShowSchemaTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND schemaname = ? AND tablename = ?"
+	postgresqlShowSearchPathStatement           = "SELECT 't' FROM pg_db_role_setting AS s JOIN pg_roles AS r ON r.oid = s.setrole JOIN pg_database AS d ON d.oid = s.setdatabase WHERE r.rolname = ? AND d.datname = ? AND ? = ANY(s.setconfig)"
+	postgresqlGrantConnectStatement             = "GRANT CONNECT ON DATABASE %s TO %s"
+	postgresqlChangeSchemaOwnerStatement        = "ALTER SCHEMA %s OWNER TO %s"
+	postgresqlSetSearchPathStatement            = "ALTER ROLE %s IN DATABASE %s SET search_path TO %s"
+	postgresqlResetSearchPathStatement          = "ALTER ROLE %s IN DATABASE %s RESET search_path"
+	// The following statements are used to enforce the user policy, the settings are applied to all the databases.
+	postgresqlFetchUserPolicyStatement    = "SELECT rolconnlimit, rolconfig FROM pg_roles WHERE rolname = ?"
+	postgresqlSetConnectionLimitStatement = "ALTER ROLE %s CONNECTION LIMIT %d"
+	postgresqlSetUserSettingStatement     = "ALTER ROLE %s SET %s = %d"
+	postgresqlResetUserSettingStatement   = "ALTER ROLE %s RESET %s"
 )
 
 type PostgreSQLConnection struct {
 	db               *pg.DB
 	connection       ConnectionEndpoint
 	switchDatabaseFn func(dbName string) *pg.DB
+	// sharedDatabase is the database hosting the tenant schemas when using the Schema isolation:
+	// when empty, a database is created for each tenant.
+	sharedDatabase string
 }
 
 func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
@@ -49,11 +73,17 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		}
 	}
 
-	targetConn := target.(*PostgreSQLConnection).switchDatabaseFn(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
+	schema := tcp.Status.Storage.Setup.Schema
+
+	targetPg := target.(*PostgreSQLConnection) //nolint:forcetypeassert
+	targetConn := targetPg.switchDatabaseFn(targetPg.tenantDatabase(schema))
+	defer targetConn.Close()
+
+	targetTable := targetPg.kineTable(schema)
 
 	err := targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
 		for _, stm := range []string{
-			`CREATE TABLE IF NOT EXISTS kine (
+			`CREATE TABLE IF NOT EXISTS ` + targetTable + ` (
 				id SERIAL PRIMARY KEY,
 				name VARCHAR(630),
 				created INTEGER,
@@ -64,12 +94,12 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 				value bytea,
 				old_value bytea
 			)`,
-			`TRUNCATE TABLE kine`,
-			`CREATE INDEX IF NOT EXISTS kine_name_index ON kine (name)`,
-			`CREATE INDEX IF NOT EXISTS kine_name_id_index ON kine (name,id)`,
-			`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON kine (id,deleted)`,
-			`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON kine (prev_revision)`,
-			`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON kine (name, prev_revision)`,
+			`TRUNCATE TABLE ` + targetTable,
+			`CREATE INDEX IF NOT EXISTS kine_name_index ON ` + targetTable + ` (name)`,
+			`CREATE INDEX IF NOT EXISTS kine_name_id_index ON ` + targetTable + ` (name,id)`,
+			`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON ` + targetTable + ` (id,deleted)`,
+			`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON ` + targetTable + ` (prev_revision)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON ` + targetTable + ` (name, prev_revision)`,
 		} {
 			if _, err := tx.ExecContext(ctx, stm); err != nil {
 				return fmt.Errorf("unable to perform schema creation: %w", err)
@@ -78,11 +108,14 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		// Dumping the old datastore in a local buffer
 		var buf bytes.Buffer
 
-		if _, err := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema).WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil { //nolint:contextcheck
+		originConn := r.switchDatabaseFn(r.tenantDatabase(schema))
+		defer originConn.Close()
+
+		if _, err := originConn.WithContext(ctx).CopyTo(&buf, "COPY "+r.kineTable(schema)+" TO STDOUT"); err != nil { //nolint:contextcheck
 			return fmt.Errorf("unable to copy from the origin datastore: %w", err)
 		}
 
-		if _, err := tx.CopyFrom(&buf, "COPY kine FROM STDIN"); err != nil {
+		if _, err := tx.CopyFrom(&buf, "COPY "+targetTable+" FROM STDIN"); err != nil {
 			return fmt.Errorf("unable to copy to the target datastore: %w", err)
 		}
 
@@ -95,7 +128,9 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 	return nil
 }
 
-func NewPostgreSQLConnection(config ConnectionConfig) (Connection, error) {
+// NewPostgreSQLConnection returns the connection to the PostgreSQL server: when the shared database is not empty,
+// the Schema isolation is used, and the tenant data is stored in its own schema of the shared database.
+func NewPostgreSQLConnection(config ConnectionConfig, sharedDatabase string) (Connection, error) {
 	opt := &pg.Options{
 		Addr:      config.Endpoints[0].String(),
 		Database:  config.DBName,
@@ -105,16 +140,18 @@ func NewPostgreSQLConnection(config ConnectionConfig) (Connection, error) {
 	}
 
 	fn := func(dbName string) *pg.DB {
-		o := opt
+		// Copying the options since they're retained by the returned connection pool.
+		o := *opt
 		o.Database = dbName
 
-		return pg.Connect(o)
+		return pg.Connect(&o)
 	}
 
 	return &PostgreSQLConnection{
 		db:               pg.Connect(opt),
 		switchDatabaseFn: fn,
 		connection:       config.Endpoints[0],
+		sharedDatabase:   sharedDatabase,
 	}, nil
 }
 
@@ -122,7 +159,11 @@ func (r *PostgreSQLConnection) Driver() string {
 	return string(kamajiv1alpha1.KinePostgreSQLDriver)
 }
 
-func (r *PostgreSQLConnection) UserExists(ctx context.Context, user string) (bool, error) {
+func (r *PostgreSQLConnection) UserExists(ctx context.Context, user string, policy *kamajiv1alpha1.DataStoreUserPolicy) (bool, error) {
+	if policy != nil {
+		return r.userPolicyExists(ctx, user, *policy)
+	}
+
 	res, err := r.db.ExecContext(ctx, postgresqlUserExists, user)
 	if err != nil {
 		return false, errors.NewCheckUserExistsError(err)
@@ -131,16 +172,84 @@ func (r *PostgreSQLConnection) UserExists(ctx context.Context, user string) (boo
 	return res.RowsReturned() > 0, nil
 }
 
-func (r *PostgreSQLConnection) CreateUser(ctx context.Context, user, password string) error {
-	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateUserStatement, user), password)
+// userPolicyExists checks if the role has the expected connection limit, and timeout settings.
+func (r *PostgreSQLConnection) userPolicyExists(ctx context.Context, user string, policy kamajiv1alpha1.DataStoreUserPolicy) (bool, error) {
+	var connectionLimit int64
+	var config []string
+
+	if _, err := r.db.QueryOneContext(ctx, pg.Scan(&connectionLimit, pg.Array(&config)), postgresqlFetchUserPolicyStatement, user); err != nil {
+		if goerrors.Is(err, pg.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, errors.NewCheckUserExistsError(err)
+	}
+
+	if connectionLimit != postgresqlConnectionLimit(policy) {
+		return false, nil
+	}
+
+	current := make(map[string]string, len(config))
+
+	for _, setting := range config {
+		if name, value, ok := strings.Cut(setting, "="); ok {
+			current[name] = value
+		}
+	}
+
+	for name, value := range postgresqlUserSettings(policy) {
+		currentValue, ok := current[name]
+
+		switch {
+		case value == 0 && ok:
+			return false, nil
+		case value > 0 && currentValue != strconv.FormatInt(value, 10):
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// CreateUser creates the role if not existing, and applies the connection limit, and timeout settings of the policy.
+func (r *PostgreSQLConnection) CreateUser(ctx context.Context, user, password string, policy kamajiv1alpha1.DataStoreUserPolicy) error {
+	exists, err := r.UserExists(ctx, user, nil)
 	if err != nil {
 		return errors.NewCreateUserError(err)
 	}
 
+	if !exists {
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateUserStatement, user), password); err != nil {
+			return errors.NewCreateUserError(err)
+		}
+	}
+
+	statements := []string{fmt.Sprintf(postgresqlSetConnectionLimitStatement, user, postgresqlConnectionLimit(policy))}
+
+	for name, value := range postgresqlUserSettings(policy) {
+		if value == 0 {
+			statements = append(statements, fmt.Sprintf(postgresqlResetUserSettingStatement, user, name))
+
+			continue
+		}
+
+		statements = append(statements, fmt.Sprintf(postgresqlSetUserSettingStatement, user, name, value))
+	}
+
+	for _, stm := range statements {
+		if _, err = r.db.ExecContext(ctx, stm); err != nil {
+			return errors.NewCreateUserError(err)
+		}
+	}
+
 	return nil
 }
 
 func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if len(r.sharedDatabase) > 0 {
+		return r.schemaExists(ctx, dbName)
+	}
+
 	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, dbName)
 	if err != nil {
 		return false, errors.NewCheckDatabaseExistError(err)
@@ -149,7 +258,31 @@ func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (boo
 	return rows.RowsReturned() > 0, nil
 }
 
+func (r *PostgreSQLConnection) schemaExists(ctx context.Context, schema string) (bool, error) {
+	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, r.sharedDatabase)
+	if err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	if rows.RowsReturned() == 0 {
+		return false, nil
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if rows, err = dbConn.ExecContext(ctx, postgresqlFetchSchemaStatement, schema); err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	return rows.RowsReturned() > 0, nil
+}
+
 func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.createSchema(ctx, dbName)
+	}
+
 	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, dbName))
 	if err != nil {
 		return errors.NewCreateDBError(err)
@@ -158,7 +291,42 @@ func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) erro
 	return nil
 }
 
+func (r *PostgreSQLConnection) createSchema(ctx context.Context, schema string) error {
+	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, r.sharedDatabase)
+	if err != nil {
+		return errors.NewCreateDBError(err)
+	}
+	// The shared database is created along with the first tenant schema: the tenants are allowed to connect to it
+	// only once their privileges are granted, and they cannot create objects in the public schema.
+	if rows.RowsReturned() == 0 {
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, r.sharedDatabase)); err != nil {
+			return errors.NewCreateDBError(err)
+		}
+
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePublicStatement, r.sharedDatabase)); err != nil {
+			return errors.NewCreateDBError(err)
+		}
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if _, err = dbConn.ExecContext(ctx, postgresqlRevokePublicSchemaStatement); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	if _, err = dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlCreateSchemaStatement, schema)); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	return nil
+}
+
 func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if len(r.sharedDatabase) > 0 {
+		return r.schemaPrivilegesExists(ctx, user, dbName)
+	}
+
 	var hasDatabasePrivilege string
 
 	_, err := r.db.QueryContext(ctx, pg.Scan(&hasDatabasePrivilege), postgresqlShowGrantsStatement, dbName, user)
@@ -181,7 +349,7 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 	dbConn := r.switchDatabaseFn(dbName)
 	defer dbConn.Close()
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return false, errors.NewGrantPrivilegesError(err)
 	}
@@ -197,7 +365,57 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 	return hasDatabasePrivilege == "t" && isOwner == "t", nil
 }
 
+func (r *PostgreSQLConnection) schemaPrivilegesExists(ctx context.Context, user, schema string) (bool, error) {
+	var canConnect string
+
+	if _, err := r.db.QueryContext(ctx, pg.Scan(&canConnect), postgresqlShowConnectGrantsStatement, r.sharedDatabase, user); err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return false, nil
+		}
+
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	var hasSearchPath string
+
+	if _, err := r.db.QueryContext(ctx, pg.Scan(&hasSearchPath), postgresqlShowSearchPathStatement, user, r.sharedDatabase, "search_path="+schema); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	var isOwner string
+
+	if _, err := dbConn.QueryContext(ctx, pg.Scan(&isOwner), postgresqlShowSchemaOwnershipStatement, schema, user); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	if tableExists {
+		var isTableOwner string
+
+		if _, err = dbConn.QueryContext(ctx, pg.Scan(&isTableOwner), postgresqlShowSchemaTableOwnershipStatement, user, schema, "kine"); err != nil {
+			return false, errors.NewCheckGrantExistsError(err)
+		}
+
+		if isTableOwner != "t" {
+			return false, nil
+		}
+	}
+
+	return canConnect == "t" && hasSearchPath == "t" && isOwner == "t", nil
+}
+
 func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.grantSchemaPrivileges(ctx, user, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantPrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -209,7 +427,7 @@ func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName
 		return errors.NewGrantPrivilegesError(err)
 	}
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -223,6 +441,37 @@ func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName
 	return nil
 }
 
+func (r *PostgreSQLConnection) grantSchemaPrivileges(ctx context.Context, user, schema string) error {
+	for _, stm := range []string{
+		fmt.Sprintf(postgresqlGrantConnectStatement, r.sharedDatabase, user),
+		fmt.Sprintf(postgresqlSetSearchPathStatement, user, r.sharedDatabase, schema),
+	} {
+		if _, err := r.db.ExecContext(ctx, stm); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlChangeSchemaOwnerStatement, schema, user)); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+	// The kine table is created by Kamaji upon a DataStore migration, thus it must be owned by the tenant.
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if tableExists {
+		if _, err = dbConn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s OWNER TO %s", r.kineTable(schema), user)); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
+	return nil
+}
+
 func (r *PostgreSQLConnection) DeleteUser(ctx context.Context, user string) error {
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropRoleStatement, user)); err != nil {
 		return errors.NewDeleteUserError(err)
@@ -232,6 +481,17 @@ func (r *PostgreSQLConnection) DeleteUser(ctx context.Context, user string) erro
 }
 
 func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		dbConn := r.switchDatabaseFn(r.sharedDatabase)
+		defer dbConn.Close()
+
+		if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlDropSchemaStatement, dbName)); err != nil {
+			return errors.NewCannotDeleteDatabaseError(err)
+		}
+
+		return nil
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropDBStatement, dbName)); err != nil {
 		return errors.NewCannotDeleteDatabaseError(err)
 	}
@@ -240,6 +500,19 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		for _, stm := range []string{
+			fmt.Sprintf(postgresqlRevokePrivilegesStatement, r.sharedDatabase, user),
+			fmt.Sprintf(postgresqlResetSearchPathStatement, user, r.sharedDatabase),
+		} {
+			if _, err := r.db.ExecContext(ctx, stm); err != nil {
+				return errors.NewRevokePrivilegesError(err)
+			}
+		}
+
+		return nil
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewRevokePrivilegesError(err)
 	}
@@ -267,12 +540,58 @@ func (r *PostgreSQLConnection) Check(ctx context.Context) error {
 	return nil
 }
 
-func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB) (bool, error) {
+func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB, schema string) (bool, error) {
 	var tableExists string
 
-	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, "public", "kine"); err != nil {
+	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, schema, "kine"); err != nil {
 		return false, err
 	}
 
 	return tableExists == "t", nil
 }
+
+// tenantDatabase returns the database storing the tenant data, which is the shared one when using the Schema isolation.
+func (r *PostgreSQLConnection) tenantDatabase(schema string) string {
+	if len(r.sharedDatabase) > 0 {
+		return r.sharedDatabase
+	}
+
+	return schema
+}
+
+// kineTable returns the kine table name, qualified by the tenant schema when using the Schema isolation.
+func (r *PostgreSQLConnection) kineTable(schema string) string {
+	if len(r.sharedDatabase) > 0 {
+		return schema + ".kine"
+	}
+
+	return "kine"
+}
+
+// postgresqlConnectionLimit returns the connection limit of the role enforcing the given policy, where -1 means no limit.
+func postgresqlConnectionLimit(policy kamajiv1alpha1.DataStoreUserPolicy) int64 {
+	if policy.MaxConnections == nil {
+		return -1
+	}
+
+	return int64(*policy.MaxConnections)
+}
+
+// postgresqlUserSettings returns the timeout settings of the role enforcing the given policy,
+// expressed in milliseconds, where zero means no timeout.
+func postgresqlUserSettings(policy kamajiv1alpha1.DataStoreUserPolicy) map[string]int64 {
+	settings := map[string]int64{
+		"statement_timeout": 0,
+		"lock_timeout":      0,
+	}
+
+	if timeout := policy.StatementTimeout; timeout != nil {
+		settings["statement_timeout"] = timeout.Milliseconds()
+	}
+
+	if timeout := policy.LockTimeout; timeout != nil {
+		settings["lock_timeout"] = timeout.Milliseconds()
+	}
+
+	return settings
+}
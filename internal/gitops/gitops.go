@@ -0,0 +1,139 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package gitops
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeconfigutil "k8s.io/kubernetes/cmd/kubeadm/app/util/kubeconfig"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Identity is both the user, and the group, of the client certificate used by the GitOps tools:
+// it's not a member of system:masters, thus it's granted the permissions of the bound ClusterRole only.
+const Identity = "kamaji:gitops"
+
+// Component is the resource label of the Secrets created for the registration.
+const Component = "gitops"
+
+const (
+	// ArgoCDSecretTypeLabel is the label used by Argo CD to discover the cluster Secrets.
+	ArgoCDSecretTypeLabel   = "argocd.argoproj.io/secret-type"
+	ArgoCDSecretTypeCluster = "cluster"
+	// FluxKubeconfigKey is the key used by default by Flux to read the kubeconfig of the referenced Secret.
+	FluxKubeconfigKey = "value"
+)
+
+// Registration is the operator-level configuration of the Tenant Clusters registration in the GitOps tools.
+type Registration struct {
+	// ArgoCDNamespace is the namespace of the Argo CD cluster Secrets, when empty the Argo CD registration is disabled.
+	ArgoCDNamespace string
+	// FluxNamespace is the namespace of the Flux kubeconfig Secrets, when empty the Flux registration is disabled.
+	FluxNamespace string
+	// ClusterRole is the Tenant Cluster ClusterRole bound to the GitOps identity.
+	ClusterRole string
+}
+
+func (r Registration) Enabled() bool {
+	return len(r.Namespaces()) > 0
+}
+
+// Namespaces returns the namespaces where the registration Secrets are created.
+func (r Registration) Namespaces() []string {
+	var namespaces []string
+
+	for _, namespace := range []string{r.ArgoCDNamespace, r.FluxNamespace} {
+		if len(namespace) > 0 {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// SecretName returns the name of the registration Secrets of the given Tenant Control Plane, used as cluster name as well:
+// the namespace is part of it, since the Secrets of all the Tenant Control Planes share the same namespace.
+func SecretName(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("%s-%s", tcp.GetNamespace(), tcp.GetName())
+}
+
+// Kubeconfig issues a client certificate of the GitOps identity signed by the given CA,
+// returning the kubeconfig using it to connect to the given server.
+func Kubeconfig(clusterName, server string, caCertificate, caPrivateKey []byte) ([]byte, error) {
+	crt, key, err := crypto.GenerateCertificatePrivateKeyPair(certificateTemplate(), caCertificate, caPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot issue the GitOps identity certificate: %w", err)
+	}
+
+	config := kubeconfigutil.CreateWithCerts(server, clusterName, Identity, caCertificate, key.Bytes(), crt.Bytes())
+
+	return clientcmd.Write(*config)
+}
+
+func certificateTemplate() *x509.Certificate {
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(mathrand.Int63()),
+		Subject: pkix.Name{
+			CommonName:   Identity,
+			Organization: []string{Identity},
+		},
+		NotBefore:   now,
+		NotAfter:    now.Add(kubeadmconstants.CertificateValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+}
+
+// argoCDClusterConfig is the subset of the Argo CD cluster configuration used by the registration.
+type argoCDClusterConfig struct {
+	TLSClientConfig argoCDTLSClientConfig `json:"tlsClientConfig"`
+}
+
+type argoCDTLSClientConfig struct {
+	CAData   []byte `json:"caData"`
+	CertData []byte `json:"certData"`
+	KeyData  []byte `json:"keyData"`
+}
+
+// ArgoCDClusterData returns the data of the Argo CD cluster Secret, using the server and the TLS settings of the given kubeconfig.
+func ArgoCDClusterData(name string, kubeconfig []byte) (map[string][]byte, error) {
+	kc, err := utilities.DecodeKubeconfigYAML(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the GitOps kubeconfig: %w", err)
+	}
+
+	if len(kc.Clusters) == 0 || len(kc.AuthInfos) == 0 {
+		return nil, fmt.Errorf("the GitOps kubeconfig is missing the cluster, or the user")
+	}
+
+	config, err := json.Marshal(argoCDClusterConfig{
+		TLSClientConfig: argoCDTLSClientConfig{
+			CAData:   kc.Clusters[0].Cluster.CertificateAuthorityData,
+			CertData: kc.AuthInfos[0].AuthInfo.ClientCertificateData,
+			KeyData:  kc.AuthInfos[0].AuthInfo.ClientKeyData,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		"name":   []byte(name),
+		"server": []byte(kc.Clusters[0].Cluster.Server),
+		"config": config,
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package gitops
+
+import (
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func TestKubeconfig(t *testing.T) {
+	caKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ca, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "kubernetes"}, caKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	caCertificate := pkiutil.EncodeCertPEM(ca)
+
+	caPrivateKey, err := keyutil.MarshalPrivateKeyToPEM(caKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	kubeconfig, err := Kubeconfig("default-tenant-00", "https://10.0.0.1:6443", caCertificate, caPrivateKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	kc, err := utilities.DecodeKubeconfigYAML(kubeconfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	certificate := kc.AuthInfos[0].AuthInfo.ClientCertificateData
+
+	if ok, verifyErr := crypto.VerifyCertificate(certificate, caCertificate, x509.ExtKeyUsageClientAuth); !ok {
+		t.Fatalf("expected the client certificate to be signed by the CA, got %v", verifyErr)
+	}
+
+	crt, err := crypto.ParseCertificateBytes(certificate)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if crt.Subject.CommonName != Identity || len(crt.Subject.Organization) != 1 || crt.Subject.Organization[0] != Identity {
+		t.Fatalf("unexpected subject %s", crt.Subject.String())
+	}
+
+	data, err := ArgoCDClusterData("default-tenant-00", kubeconfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(data["name"]) != "default-tenant-00" || string(data["server"]) != "https://10.0.0.1:6443" {
+		t.Fatalf("unexpected cluster name, or server: %s, %s", data["name"], data["server"])
+	}
+
+	config := argoCDClusterConfig{}
+	if err = json.Unmarshal(data["config"], &config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if string(config.TLSClientConfig.CAData) != string(caCertificate) || string(config.TLSClientConfig.CertData) != string(certificate) {
+		t.Fatalf("unexpected TLS client configuration")
+	}
+}
+
+func TestRegistrationNamespaces(t *testing.T) {
+	if (Registration{}).Enabled() {
+		t.Fatalf("expected the registration to be disabled")
+	}
+
+	registration := Registration{FluxNamespace: "flux-system"}
+	if namespaces := registration.Namespaces(); !registration.Enabled() || len(namespaces) != 1 || namespaces[0] != "flux-system" {
+		t.Fatalf("unexpected namespaces %v", namespaces)
+	}
+}
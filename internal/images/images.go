@@ -0,0 +1,179 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+const defaultRegistry = "docker.io"
+
+var digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// Rule is rewriting the images starting with the given prefix, such as a registry or a repository,
+// with the replacement one: the prefix is matched against the fully qualified image reference,
+// thus the images without an explicit registry must be referred to using the docker.io one.
+type Rule struct {
+	Prefix      string
+	Replacement string
+}
+
+// ParseRules parses the rewrite rules expressed in the prefix=replacement form.
+func ParseRules(values map[string]string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(values))
+
+	for prefix, replacement := range values {
+		prefix, replacement = strings.TrimSuffix(prefix, "/"), strings.TrimSuffix(replacement, "/")
+
+		if len(prefix) == 0 || len(replacement) == 0 {
+			return nil, fmt.Errorf("the registry rewrite rule %s=%s must have a non empty prefix and replacement", prefix, replacement)
+		}
+
+		rules = append(rules, Rule{Prefix: prefix, Replacement: replacement})
+	}
+	// Sorting by the prefix length, the most specific rule wins.
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].Prefix) != len(rules[j].Prefix) {
+			return len(rules[i].Prefix) > len(rules[j].Prefix)
+		}
+
+		return rules[i].Prefix < rules[j].Prefix
+	})
+
+	return rules, nil
+}
+
+// LoadDigests reads the local mirror manifest index, a YAML or JSON map of the image references to their digest,
+// e.g. "mirror.local/kube-apiserver:v1.26.1: sha256:...".
+func LoadDigests(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the image digests file: %w", err)
+	}
+
+	digests := map[string]string{}
+	if err = yaml.Unmarshal(content, &digests); err != nil {
+		return nil, fmt.Errorf("unable to decode the image digests file: %w", err)
+	}
+
+	for image, digest := range digests {
+		if !digestRegexp.MatchString(digest) {
+			return nil, fmt.Errorf("the image %s has an invalid digest %s", image, digest)
+		}
+	}
+
+	return digests, nil
+}
+
+// Policy is the operator-level policy applied to every image rendered by Kamaji.
+type Policy struct {
+	// Rules are the registry rewrite rules, sorted from the most specific one.
+	Rules []Rule
+	// Digests is the local mirror manifest index, used to pin the images upon the Tenant Control Plane admission.
+	Digests map[string]string
+}
+
+// Rewrite applies the first matching rewrite rule to the given image.
+func (p Policy) Rewrite(image string) string {
+	if len(p.Rules) == 0 {
+		return image
+	}
+
+	normalized := normalize(image)
+
+	for _, rule := range p.Rules {
+		if normalized == rule.Prefix || strings.HasPrefix(normalized, rule.Prefix+"/") {
+			return rule.Replacement + strings.TrimPrefix(normalized, rule.Prefix)
+		}
+	}
+
+	return image
+}
+
+// Image returns the image to be rendered for the given Tenant Control Plane: the rewritten image,
+// along with the digest it has been pinned to upon admission, if any.
+func (p Policy) Image(tcp *kamajiv1alpha1.TenantControlPlane, image string) string {
+	image = p.Rewrite(image)
+
+	if strings.Contains(image, "@") {
+		return image
+	}
+
+	if digest, ok := Pinned(tcp)[image]; ok {
+		return image + "@" + digest
+	}
+
+	return image
+}
+
+// Pin resolves the digests of the given images using the local mirror manifest index:
+// the already pinned digests are retained, and the images no more rendered are dropped.
+// The images missing from the index are returned as unresolved.
+func (p Policy) Pin(pinned map[string]string, images []string) (resolved map[string]string, unresolved []string) {
+	resolved = make(map[string]string, len(images))
+
+	for _, image := range images {
+		image = p.Rewrite(image)
+
+		if strings.Contains(image, "@") {
+			continue
+		}
+
+		if digest, ok := pinned[image]; ok {
+			resolved[image] = digest
+
+			continue
+		}
+
+		if digest, ok := p.Digests[image]; ok {
+			resolved[image] = digest
+
+			continue
+		}
+
+		unresolved = append(unresolved, image)
+	}
+
+	return resolved, unresolved
+}
+
+// Pinned returns the image digests pinned upon the admission of the Tenant Control Plane.
+func Pinned(tcp *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	value, ok := tcp.GetAnnotations()[constants.ImageDigests]
+	if !ok {
+		return nil
+	}
+
+	pinned := map[string]string{}
+	// A malformed value is ignored, falling back to the image tags.
+	if err := json.Unmarshal([]byte(value), &pinned); err != nil {
+		return nil
+	}
+
+	return pinned
+}
+
+// normalize returns the fully qualified image reference, adding the implicit Docker Hub registry and library repository.
+func normalize(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+
+	switch {
+	case len(parts) == 1:
+		return defaultRegistry + "/library/" + image
+	case !strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost":
+		return defaultRegistry + "/" + image
+	default:
+		return image
+	}
+}
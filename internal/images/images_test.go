@@ -0,0 +1,97 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+var digest = "sha256:" + strings.Repeat("a", 64)
+
+func TestRewrite(t *testing.T) {
+	rules, err := ParseRules(map[string]string{
+		"registry.k8s.io":                   "mirror.local/k8s",
+		"registry.k8s.io/kas-network-proxy": "mirror.local/konnectivity/",
+		"docker.io":                         "mirror.local/hub",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	policy := Policy{Rules: rules}
+
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "registry.k8s.io/kube-apiserver:v1.26.1", expected: "mirror.local/k8s/kube-apiserver:v1.26.1"},
+		{image: "registry.k8s.io/kas-network-proxy/proxy-server:v0.0.32", expected: "mirror.local/konnectivity/proxy-server:v0.0.32"},
+		{image: "rancher/kine:v0.9.2-amd64", expected: "mirror.local/hub/rancher/kine:v0.9.2-amd64"},
+		{image: "busybox", expected: "mirror.local/hub/library/busybox"},
+		{image: "registry.k8s.io.evil/kube-apiserver:v1.26.1", expected: "registry.k8s.io.evil/kube-apiserver:v1.26.1"},
+		{image: "quay.io/clastix/kamaji:latest", expected: "quay.io/clastix/kamaji:latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if actual := policy.Rewrite(tt.image); actual != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	if _, err := ParseRules(map[string]string{"registry.k8s.io": ""}); err == nil {
+		t.Fatal("expected an error for an empty replacement")
+	}
+}
+
+func TestPinAndImage(t *testing.T) {
+	other := "sha256:" + strings.Repeat("b", 64)
+
+	policy := Policy{
+		Rules: []Rule{{Prefix: "registry.k8s.io", Replacement: "mirror.local"}},
+		Digests: map[string]string{
+			"mirror.local/kube-apiserver:v1.26.1":          digest,
+			"mirror.local/kube-controller-manager:v1.26.1": digest,
+		},
+	}
+
+	resolved, unresolved := policy.Pin(
+		map[string]string{"mirror.local/kube-controller-manager:v1.26.1": other, "mirror.local/kube-apiserver:v1.25.0": other},
+		[]string{"registry.k8s.io/kube-apiserver:v1.26.1", "registry.k8s.io/kube-controller-manager:v1.26.1", "registry.k8s.io/kube-scheduler:v1.26.1"},
+	)
+
+	expected := map[string]string{
+		"mirror.local/kube-apiserver:v1.26.1":          digest,
+		"mirror.local/kube-controller-manager:v1.26.1": other,
+	}
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Fatalf("expected %v, got %v", expected, resolved)
+	}
+
+	if !reflect.DeepEqual(unresolved, []string{"mirror.local/kube-scheduler:v1.26.1"}) {
+		t.Fatalf("expected the scheduler image to be unresolved, got %v", unresolved)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{constants.ImageDigests: `{"mirror.local/kube-apiserver:v1.26.1":"` + digest + `"}`},
+	}}
+
+	if actual := policy.Image(tcp, "registry.k8s.io/kube-apiserver:v1.26.1"); actual != "mirror.local/kube-apiserver:v1.26.1@"+digest {
+		t.Fatalf("expected the pinned image, got %s", actual)
+	}
+	// The index is consulted only upon admission: the images not pinned are rendered using their tag.
+	if actual := policy.Image(tcp, "registry.k8s.io/kube-controller-manager:v1.26.1"); actual != "mirror.local/kube-controller-manager:v1.26.1" {
+		t.Fatalf("expected the tagged image, got %s", actual)
+	}
+}
@@ -4,12 +4,20 @@
 package kubeadm
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmscheme "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/scheme"
+	kubeadmv1beta3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta3"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/util/config"
+	"sigs.k8s.io/yaml"
 
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -73,6 +81,81 @@ func CreateKubeadmInitConfiguration(params Parameters) (*Configuration, error) {
 	return &Configuration{InitConfiguration: *conf}, nil
 }
 
+// MergeClusterConfiguration merges the given partial ClusterConfiguration, in YAML or JSON format using the
+// kubeadm v1beta3 API, into the generated one: the fields managed by Kamaji cannot be changed,
+// and the API Server certificate SANs are added to the generated ones.
+func MergeClusterConfiguration(conf *Configuration, patch string) error {
+	if len(strings.TrimSpace(patch)) == 0 {
+		return nil
+	}
+
+	current := &conf.InitConfiguration.ClusterConfiguration
+
+	body, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return errors.Wrap(err, "cannot decode the ClusterConfiguration")
+	}
+
+	if err = yaml.UnmarshalStrict(body, &kubeadmv1beta3.ClusterConfiguration{}); err != nil {
+		return errors.Wrap(err, "cannot decode the ClusterConfiguration")
+	}
+
+	versioned := kubeadmv1beta3.ClusterConfiguration{}
+	if err = kubeadmscheme.Scheme.Convert(current, &versioned, nil); err != nil {
+		return errors.Wrap(err, "cannot convert the generated ClusterConfiguration")
+	}
+
+	original, err := json.Marshal(versioned)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode the generated ClusterConfiguration")
+	}
+
+	merged, err := jsonpatch.MergePatch(original, body)
+	if err != nil {
+		return errors.Wrap(err, "cannot merge the ClusterConfiguration")
+	}
+
+	versioned = kubeadmv1beta3.ClusterConfiguration{}
+	if err = json.Unmarshal(merged, &versioned); err != nil {
+		return errors.Wrap(err, "cannot decode the merged ClusterConfiguration")
+	}
+
+	clusterConfiguration := kubeadmapi.ClusterConfiguration{}
+	if err = kubeadmscheme.Scheme.Convert(&versioned, &clusterConfiguration, nil); err != nil {
+		return errors.Wrap(err, "cannot convert the merged ClusterConfiguration")
+	}
+
+	switch {
+	case clusterConfiguration.ControlPlaneEndpoint != current.ControlPlaneEndpoint:
+		return fmt.Errorf("the ClusterConfiguration controlPlaneEndpoint is managed by Kamaji and cannot be changed")
+	case !apiequality.Semantic.DeepEqual(clusterConfiguration.Etcd, current.Etcd):
+		return fmt.Errorf("the ClusterConfiguration etcd is managed by Kamaji and cannot be changed")
+	case clusterConfiguration.CertificatesDir != current.CertificatesDir:
+		return fmt.Errorf("the ClusterConfiguration certificatesDir is managed by Kamaji and cannot be changed")
+	case clusterConfiguration.Networking != current.Networking:
+		return fmt.Errorf("the ClusterConfiguration networking is managed by Kamaji and cannot be changed")
+	case clusterConfiguration.KubernetesVersion != current.KubernetesVersion:
+		return fmt.Errorf("the ClusterConfiguration kubernetesVersion is managed by Kamaji and cannot be changed")
+	case clusterConfiguration.ClusterName != current.ClusterName:
+		return fmt.Errorf("the ClusterConfiguration clusterName is managed by Kamaji and cannot be changed")
+	}
+	// The generated SANs are required by the in-cluster clients, and the merge patch is replacing the lists.
+	certSANs := sets.NewString(current.APIServer.CertSANs...)
+	for _, san := range clusterConfiguration.APIServer.CertSANs {
+		if !certSANs.Has(san) {
+			certSANs.Insert(san)
+
+			current.APIServer.CertSANs = append(current.APIServer.CertSANs, san)
+		}
+	}
+
+	clusterConfiguration.APIServer.CertSANs = current.APIServer.CertSANs
+	clusterConfiguration.ComponentConfigs = current.ComponentConfigs
+	*current = clusterConfiguration
+
+	return nil
+}
+
 func GetKubeadmInitConfigurationMap(config Configuration) (map[string]string, error) {
 	initConfigurationString, err := utilities.EncodeToJSON(&config.InitConfiguration)
 	if err != nil {
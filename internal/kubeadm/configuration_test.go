@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"testing"
+)
+
+func TestMergeClusterConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr bool
+	}{
+		{name: "certSANs", patch: "apiServer:\n  certSANs:\n  - api.example.com\n  - localhost\n"},
+		{name: "feature gates", patch: "featureGates:\n  PublicKeysECDSA: true\n"},
+		{name: "etcd", patch: "etcd:\n  local:\n    dataDir: /var/lib/etcd\n", wantErr: true},
+		{name: "service subnet", patch: "networking:\n  serviceSubnet: 10.0.0.0/16\n", wantErr: true},
+		{name: "DNS domain", patch: "networking:\n  dnsDomain: example.com\n", wantErr: true},
+		{name: "Kubernetes version", patch: "kubernetesVersion: v1.25.0\n", wantErr: true},
+		{name: "cluster name", patch: "clusterName: other\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := CreateKubeadmInitConfiguration(Parameters{
+				TenantControlPlaneName:        "tenant",
+				TenantControlPlaneNamespace:   "default",
+				TenantControlPlaneAddress:     "10.0.0.1",
+				TenantControlPlaneEndpoint:    "10.0.0.1:6443",
+				TenantControlPlanePort:        6443,
+				TenantControlPlanePodCIDR:     "10.244.0.0/16",
+				TenantControlPlaneServiceCIDR: "10.96.0.0/16",
+				TenantControlPlaneVersion:     "v1.26.0",
+				ETCDs:                         []string{"https://127.0.0.1:2379"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			generated := append([]string{}, config.InitConfiguration.APIServer.CertSANs...)
+
+			err = MergeClusterConfiguration(config, tt.patch)
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case tt.wantErr:
+				return
+			}
+
+			certSANs := config.InitConfiguration.APIServer.CertSANs
+			for i, san := range generated {
+				if certSANs[i] != san {
+					t.Fatalf("expected the generated SANs to be retained, got %v", certSANs)
+				}
+			}
+
+			if tt.name == "certSANs" && (len(certSANs) != len(generated)+1 || certSANs[len(certSANs)-1] != "api.example.com") {
+				t.Fatalf("expected the SAN to be added, got %v", certSANs)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmv1beta3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Keys of the join artifacts.
+const (
+	JoinConfigurationKey = "join-configuration.yaml"
+	CloudInitUserDataKey = "user-data"
+	IgnitionConfigKey    = "ignition.json"
+)
+
+const (
+	joinConfigurationPath = "/etc/kubeadm/join-configuration.yaml"
+	joinPatchesDirectory  = "/etc/kubeadm/patches"
+	joinServiceName       = "kubeadm-join.service"
+	ignitionVersion       = "3.3.0"
+)
+
+// JoinParameters are the settings required by a worker node to join the Tenant Cluster.
+type JoinParameters struct {
+	APIServerEndpoint string
+	Token             string
+	CACertHash        string
+	// CGroupDriver is the kubelet cgroup driver, matching the one of the kubelet configuration uploaded to the Tenant Cluster.
+	CGroupDriver string
+}
+
+type joinFile struct {
+	path    string
+	content []byte
+}
+
+// JoinArtifacts renders the kubeadm JoinConfiguration, along with the cloud-init user-data, and the Ignition config,
+// writing it to the node and running kubeadm join upon the first boot.
+func JoinArtifacts(params JoinParameters) (map[string][]byte, error) {
+	joinConfiguration, err := joinConfigurationContent(params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render the JoinConfiguration: %w", err)
+	}
+
+	files := []joinFile{{path: joinConfigurationPath, content: joinConfiguration}}
+	// The kubelet configuration is downloaded from the Tenant Cluster upon join:
+	// the cgroup driver declared by the Tenant Control Plane is enforced using a kubeadm patch as well.
+	if len(params.CGroupDriver) > 0 {
+		files = append(files, joinFile{path: joinPatchesDirectory + "/kubeletconfiguration+strategic.yaml", content: []byte(fmt.Sprintf("cgroupDriver: %s\n", params.CGroupDriver))})
+	}
+
+	userData, err := cloudInitUserData(files)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render the cloud-init user-data: %w", err)
+	}
+
+	ignition, err := ignitionConfig(files)
+	if err != nil {
+		return nil, fmt.Errorf("cannot render the Ignition config: %w", err)
+	}
+
+	return map[string][]byte{
+		JoinConfigurationKey: joinConfiguration,
+		CloudInitUserDataKey: userData,
+		IgnitionConfigKey:    ignition,
+	}, nil
+}
+
+func joinConfigurationContent(params JoinParameters) ([]byte, error) {
+	joinConfiguration := &kubeadmv1beta3.JoinConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kubeadmv1beta3.SchemeGroupVersion.String(),
+			Kind:       "JoinConfiguration",
+		},
+		Discovery: kubeadmv1beta3.Discovery{
+			BootstrapToken: &kubeadmv1beta3.BootstrapTokenDiscovery{
+				Token:             params.Token,
+				APIServerEndpoint: params.APIServerEndpoint,
+				CACertHashes:      []string{params.CACertHash},
+			},
+		},
+	}
+
+	if len(params.CGroupDriver) > 0 {
+		joinConfiguration.Patches = &kubeadmv1beta3.Patches{Directory: joinPatchesDirectory}
+	}
+
+	return utilities.EncodeToYaml(joinConfiguration)
+}
+
+func joinCommand() string {
+	return fmt.Sprintf("kubeadm join --config %s", joinConfigurationPath)
+}
+
+func cloudInitUserData(files []joinFile) ([]byte, error) {
+	type writeFile struct {
+		Path        string `json:"path"`
+		Permissions string `json:"permissions"`
+		Owner       string `json:"owner"`
+		Content     string `json:"content"`
+	}
+
+	writeFiles := make([]writeFile, 0, len(files))
+	for _, file := range files {
+		writeFiles = append(writeFiles, writeFile{Path: file.path, Permissions: "0600", Owner: "root:root", Content: string(file.content)})
+	}
+
+	content, err := yaml.Marshal(map[string]interface{}{
+		"write_files": writeFiles,
+		"runcmd":      []string{joinCommand()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("#cloud-config\n"), content...), nil
+}
+
+func ignitionConfig(files []joinFile) ([]byte, error) {
+	type fileContents struct {
+		Source string `json:"source"`
+	}
+
+	type file struct {
+		Path      string       `json:"path"`
+		Mode      int          `json:"mode"`
+		Overwrite bool         `json:"overwrite"`
+		Contents  fileContents `json:"contents"`
+	}
+
+	type unit struct {
+		Name     string `json:"name"`
+		Enabled  bool   `json:"enabled"`
+		Contents string `json:"contents"`
+	}
+
+	ignitionFiles := make([]file, 0, len(files))
+	for _, f := range files {
+		ignitionFiles = append(ignitionFiles, file{
+			Path:      f.path,
+			Mode:      0o600,
+			Overwrite: true,
+			Contents:  fileContents{Source: "data:;base64," + base64.StdEncoding.EncodeToString(f.content)},
+		})
+	}
+	// The join is performed once, the kubelet kubeconfig is written by kubeadm upon a successful one.
+	joinUnit := unit{
+		Name:    joinServiceName,
+		Enabled: true,
+		Contents: fmt.Sprintf(`[Unit]
+Description=Join the Tenant Cluster using kubeadm
+Wants=network-online.target
+After=network-online.target
+ConditionPathExists=!/etc/kubernetes/kubelet.conf
+
+[Service]
+Type=oneshot
+ExecStart=/usr/bin/%s
+
+[Install]
+WantedBy=multi-user.target
+`, joinCommand()),
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"ignition": map[string]string{"version": ignitionVersion},
+		"storage":  map[string]interface{}{"files": ignitionFiles},
+		"systemd":  map[string]interface{}{"units": []unit{joinUnit}},
+	})
+}
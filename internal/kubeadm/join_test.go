@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kubeadmv1beta3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta3"
+	"sigs.k8s.io/yaml"
+)
+
+func TestJoinArtifacts(t *testing.T) {
+	artifacts, err := JoinArtifacts(JoinParameters{
+		APIServerEndpoint: "10.0.0.1:6443",
+		Token:             "abcdef.0123456789abcdef",
+		CACertHash:        "sha256:0123",
+		CGroupDriver:      "systemd",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	joinConfiguration := kubeadmv1beta3.JoinConfiguration{}
+	if err = yaml.Unmarshal(artifacts[JoinConfigurationKey], &joinConfiguration); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if discovery := joinConfiguration.Discovery.BootstrapToken; discovery == nil || discovery.APIServerEndpoint != "10.0.0.1:6443" || discovery.Token != "abcdef.0123456789abcdef" || discovery.CACertHashes[0] != "sha256:0123" {
+		t.Fatalf("unexpected discovery %v", joinConfiguration.Discovery)
+	}
+
+	if joinConfiguration.Patches == nil || joinConfiguration.Patches.Directory != joinPatchesDirectory {
+		t.Fatalf("expected the patches directory, got %v", joinConfiguration.Patches)
+	}
+
+	if userData := string(artifacts[CloudInitUserDataKey]); !strings.HasPrefix(userData, "#cloud-config\n") || !strings.Contains(userData, "cgroupDriver: systemd") {
+		t.Fatalf("unexpected cloud-init user-data %s", userData)
+	}
+
+	ignition := struct {
+		Storage struct {
+			Files []struct {
+				Path     string `json:"path"`
+				Contents struct {
+					Source string `json:"source"`
+				} `json:"contents"`
+			} `json:"files"`
+		} `json:"storage"`
+	}{}
+	if err = json.Unmarshal(artifacts[IgnitionConfigKey], &ignition); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(ignition.Storage.Files) != 2 || ignition.Storage.Files[0].Path != joinConfigurationPath {
+		t.Fatalf("unexpected Ignition files %v", ignition.Storage.Files)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ignition.Storage.Files[0].Contents.Source, "data:;base64,"))
+	if err != nil || !bytes.Equal(content, artifacts[JoinConfigurationKey]) {
+		t.Fatalf("expected the Ignition file to match the JoinConfiguration, got %s (%v)", content, err)
+	}
+}
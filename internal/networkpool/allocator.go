@@ -0,0 +1,150 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpool
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// ErrExhausted is returned when all the addresses, or the ports, of a pool have been allocated.
+var ErrExhausted = errors.New("the network pool is exhausted")
+
+// NextAddress returns the first address of the given ranges which is not included in the used ones.
+// The ranges are expressed in CIDR notation, or as an inclusive range of addresses separated by a dash:
+// for the IPv4 CIDRs, the network and the broadcast addresses are skipped.
+func NextAddress(ranges []string, used sets.String) (string, error) {
+	for _, value := range ranges {
+		first, last, err := parseRange(value)
+		if err != nil {
+			return "", err
+		}
+
+		for addr := first; ; addr = addr.Next() {
+			if !used.Has(addr.String()) {
+				return addr.String(), nil
+			}
+
+			if addr == last {
+				break
+			}
+		}
+	}
+
+	return "", errors.Wrap(ErrExhausted, "no available addresses")
+}
+
+// NextPort returns the first port of the given ranges which is not included in the used ones.
+func NextPort(ranges []kamajiv1alpha1.PortRange, used sets.Int32) (int32, error) {
+	for _, r := range ranges {
+		if r.From > r.To {
+			return 0, fmt.Errorf("invalid port range %d-%d", r.From, r.To)
+		}
+
+		for port := r.From; port <= r.To; port++ {
+			if !used.Has(port) {
+				return port, nil
+			}
+		}
+	}
+
+	return 0, errors.Wrap(ErrExhausted, "no available ports")
+}
+
+// TenantControlPlanePorts returns the API Server, its Service and NodePort, and the Konnectivity server,
+// ports declared by the Tenant Control Plane.
+func TenantControlPlanePorts(tcp *kamajiv1alpha1.TenantControlPlane) sets.Int32 {
+	ports := sets.NewInt32()
+
+	for _, port := range []int32{tcp.Spec.NetworkProfile.Port, tcp.Spec.NetworkProfile.ServicePort, tcp.Spec.NetworkProfile.NodePort} {
+		if port > 0 {
+			ports.Insert(port)
+		}
+	}
+
+	if tcp.Spec.Addons.Konnectivity != nil && tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port > 0 {
+		ports.Insert(tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+	}
+
+	return ports
+}
+
+// Validate checks the addresses, and the ports, ranges of the given pool.
+func Validate(spec kamajiv1alpha1.NetworkPoolSpec) error {
+	for _, value := range spec.Addresses {
+		if _, _, err := parseRange(value); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range spec.Ports {
+		if r.From > r.To {
+			return fmt.Errorf("invalid port range %d-%d", r.From, r.To)
+		}
+	}
+
+	return nil
+}
+
+func parseRange(value string) (netip.Addr, netip.Addr, error) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, errors.Wrap(err, fmt.Sprintf("invalid CIDR %s", value))
+		}
+
+		prefix = prefix.Masked()
+
+		first, last := prefix.Addr(), lastAddress(prefix)
+
+		if first.Is4() && prefix.Bits() < 31 {
+			first, last = first.Next(), last.Prev()
+		}
+
+		return first, last, nil
+	}
+
+	parts := strings.SplitN(value, "-", 2)
+	if len(parts) != 2 {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid address range %s, expected CIDR notation or a range of addresses separated by a dash", value)
+	}
+
+	first, err := netip.ParseAddr(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, errors.Wrap(err, fmt.Sprintf("invalid address range %s", value))
+	}
+
+	last, err := netip.ParseAddr(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, errors.Wrap(err, fmt.Sprintf("invalid address range %s", value))
+	}
+
+	if first.BitLen() != last.BitLen() || first.Compare(last) > 0 {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid address range %s", value)
+	}
+
+	return first, last, nil
+}
+
+// lastAddress returns the last address of the given prefix, setting all the host bits.
+func lastAddress(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().As16()
+	// IPv4 addresses are mapped to the last 32 bits.
+	for bit := 128 - prefix.Addr().BitLen() + prefix.Bits(); bit < 128; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+
+	addr := netip.AddrFrom16(bytes)
+	if prefix.Addr().Is4() {
+		return addr.Unmap()
+	}
+
+	return addr
+}
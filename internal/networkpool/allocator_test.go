@@ -0,0 +1,138 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package networkpool
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		first   string
+		last    string
+		wantErr bool
+	}{
+		{name: "IPv4 CIDR skips network and broadcast", value: "192.168.1.0/28", first: "192.168.1.1", last: "192.168.1.14"},
+		{name: "IPv4 CIDR is masked", value: "192.168.3.7/16", first: "192.168.0.1", last: "192.168.255.254"},
+		{name: "IPv4 /31 keeps both addresses", value: "10.0.0.0/31", first: "10.0.0.0", last: "10.0.0.1"},
+		{name: "IPv4 /32", value: "10.0.0.1/32", first: "10.0.0.1", last: "10.0.0.1"},
+		{name: "IPv6 CIDR", value: "fd00::/120", first: "fd00::", last: "fd00::ff"},
+		{name: "inclusive range", value: "10.0.0.10-10.0.0.20", first: "10.0.0.10", last: "10.0.0.20"},
+		{name: "inclusive range with spaces", value: "10.0.0.10 - 10.0.0.20", first: "10.0.0.10", last: "10.0.0.20"},
+		{name: "single address range", value: "10.0.0.10-10.0.0.10", first: "10.0.0.10", last: "10.0.0.10"},
+		{name: "reversed range", value: "10.0.0.20-10.0.0.10", wantErr: true},
+		{name: "mixed families range", value: "10.0.0.1-fd00::1", wantErr: true},
+		{name: "invalid CIDR", value: "10.0.0.0/33", wantErr: true},
+		{name: "invalid address", value: "10.0.0.256-10.0.0.300", wantErr: true},
+		{name: "single address", value: "10.0.0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, err := parseRange(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got range %s-%s", first, last)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if first.String() != tt.first || last.String() != tt.last {
+				t.Fatalf("expected range %s-%s, got %s-%s", tt.first, tt.last, first, last)
+			}
+		})
+	}
+}
+
+func TestNextAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		ranges    []string
+		used      sets.String
+		expected  string
+		exhausted bool
+		wantErr   bool
+	}{
+		{name: "first available", ranges: []string{"10.0.0.0/29"}, used: sets.NewString(), expected: "10.0.0.1"},
+		{name: "skips used", ranges: []string{"10.0.0.0/29"}, used: sets.NewString("10.0.0.1", "10.0.0.2"), expected: "10.0.0.3"},
+		{name: "moves to the next range", ranges: []string{"10.0.0.0/30", "10.0.1.5-10.0.1.6"}, used: sets.NewString("10.0.0.1", "10.0.0.2"), expected: "10.0.1.5"},
+		{name: "exhausted", ranges: []string{"10.0.0.0/30"}, used: sets.NewString("10.0.0.1", "10.0.0.2"), exhausted: true},
+		{name: "last address of the family", ranges: []string{"255.255.255.255/32"}, used: sets.NewString("255.255.255.255"), exhausted: true},
+		{name: "IPv6", ranges: []string{"fd00::/64"}, used: sets.NewString("fd00::"), expected: "fd00::1"},
+		{name: "no ranges", ranges: nil, used: sets.NewString(), exhausted: true},
+		{name: "invalid range", ranges: []string{"foo"}, used: sets.NewString(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := NextAddress(tt.ranges, tt.used)
+
+			switch {
+			case tt.exhausted:
+				if !errors.Is(err, ErrExhausted) {
+					t.Fatalf("expected exhausted error, got %q, %v", address, err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.Is(err, ErrExhausted) {
+					t.Fatalf("expected parsing error, got %q, %v", address, err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case address != tt.expected:
+				t.Fatalf("expected %s, got %s", tt.expected, address)
+			}
+		})
+	}
+}
+
+func TestNextPort(t *testing.T) {
+	tests := []struct {
+		name      string
+		ranges    []kamajiv1alpha1.PortRange
+		used      sets.Int32
+		expected  int32
+		exhausted bool
+		wantErr   bool
+	}{
+		{name: "first available", ranges: []kamajiv1alpha1.PortRange{{From: 31000, To: 31010}}, used: sets.NewInt32(), expected: 31000},
+		{name: "skips used", ranges: []kamajiv1alpha1.PortRange{{From: 31000, To: 31010}}, used: sets.NewInt32(31000, 31001), expected: 31002},
+		{name: "moves to the next range", ranges: []kamajiv1alpha1.PortRange{{From: 31000, To: 31000}, {From: 32000, To: 32001}}, used: sets.NewInt32(31000), expected: 32000},
+		{name: "exhausted", ranges: []kamajiv1alpha1.PortRange{{From: 31000, To: 31001}}, used: sets.NewInt32(31000, 31001), exhausted: true},
+		{name: "upper bound", ranges: []kamajiv1alpha1.PortRange{{From: 65535, To: 65535}}, used: sets.NewInt32(), expected: 65535},
+		{name: "invalid range", ranges: []kamajiv1alpha1.PortRange{{From: 31001, To: 31000}}, used: sets.NewInt32(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, err := NextPort(tt.ranges, tt.used)
+
+			switch {
+			case tt.exhausted:
+				if !errors.Is(err, ErrExhausted) {
+					t.Fatalf("expected exhausted error, got %d, %v", port, err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.Is(err, ErrExhausted) {
+					t.Fatalf("expected range error, got %d, %v", port, err)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case port != tt.expected:
+				t.Fatalf("expected %d, got %d", tt.expected, port)
+			}
+		})
+	}
+}
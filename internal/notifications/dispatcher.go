@@ -0,0 +1,228 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	workers = 4
+	// maxBackoff caps the delay between two subsequent delivery attempts.
+	maxBackoff = 5 * time.Minute
+)
+
+var deliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kamaji_notification_deliveries_total",
+	Help: "Total number of the Tenant Control Plane lifecycle events delivered to the sinks, or failed after the retries.",
+}, []string{"configuration", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(deliveries)
+}
+
+type delivery struct {
+	configuration string
+	event         Event
+	attempt       int32
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=notificationconfigurations,verbs=get;list;watch
+
+// Dispatcher notifies the Tenant Control Plane lifecycle events to the sinks of the NotificationConfiguration resources,
+// detecting them from the Tenant Control Plane updates and deletions: it runs on the leader instance only,
+// thus the events occurring during a leader election are not notified.
+type Dispatcher struct {
+	Client client.Client
+
+	cache    cache.Informers
+	recorder record.EventRecorder
+	queue    workqueue.DelayingInterface
+}
+
+func (d *Dispatcher) Start(ctx context.Context) error {
+	d.queue = workqueue.NewNamedDelayingQueue("notifications")
+	defer d.queue.ShutDown()
+
+	informer, err := d.cache.GetInformer(ctx, &kamajiv1alpha1.TenantControlPlane{})
+	if err != nil {
+		return err
+	}
+
+	if _, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			previous, okPrevious := oldObj.(*kamajiv1alpha1.TenantControlPlane)
+			current, okCurrent := newObj.(*kamajiv1alpha1.TenantControlPlane)
+			if !okPrevious || !okCurrent {
+				return
+			}
+
+			d.notify(ctx, current, Diff(previous, current)...)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
+			if tcp, ok := obj.(*kamajiv1alpha1.TenantControlPlane); ok {
+				d.notify(ctx, tcp, Deleted(tcp))
+			}
+		},
+	}); err != nil {
+		return err
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, d.worker, time.Second)
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// notify enqueues the deliveries of the given events to the sinks of the matching configurations.
+func (d *Dispatcher) notify(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx).WithName("notifications")
+
+	configurations := &kamajiv1alpha1.NotificationConfigurationList{}
+	if err := d.Client.List(ctx, configurations); err != nil {
+		logger.Error(err, "cannot list the notification configurations")
+
+		return
+	}
+
+	for _, configuration := range configurations.Items {
+		for _, event := range events {
+			ok, err := Matches(configuration.Spec, tcp, event)
+			if err != nil {
+				logger.Error(err, "cannot match the event", "configuration", configuration.GetName())
+
+				continue
+			}
+
+			if ok {
+				d.queue.Add(&delivery{configuration: configuration.GetName(), event: event})
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) worker(ctx context.Context) {
+	for d.process(ctx) {
+	}
+}
+
+func (d *Dispatcher) process(ctx context.Context) bool {
+	item, shutdown := d.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer d.queue.Done(item)
+
+	d.deliver(ctx, item.(*delivery)) //nolint:forcetypeassert
+
+	return true
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, item *delivery) {
+	logger := log.FromContext(ctx).WithName("notifications").WithValues("configuration", item.configuration, "type", item.event.Type, "id", item.event.ID)
+
+	configuration := &kamajiv1alpha1.NotificationConfiguration{}
+	if err := d.Client.Get(ctx, k8stypes.NamespacedName{Name: item.configuration}, configuration); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot retrieve the notification configuration")
+		}
+
+		return
+	}
+
+	err := d.send(ctx, configuration, item.event)
+	if err == nil {
+		deliveries.WithLabelValues(item.configuration, "delivered").Inc()
+
+		return
+	}
+
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) && deliveryErr.Retryable && item.attempt < configuration.Spec.Retry.MaxRetries {
+		backoff := configuration.Spec.Retry.Backoff.Duration << item.attempt
+		if backoff > maxBackoff || backoff < 0 {
+			backoff = maxBackoff
+		}
+
+		item.attempt++
+
+		logger.Info("event delivery failed, retrying", "attempt", item.attempt, "after", backoff.String(), "error", err.Error())
+
+		d.queue.AddAfter(item, backoff)
+
+		return
+	}
+
+	logger.Error(err, "cannot deliver the event")
+
+	deliveries.WithLabelValues(item.configuration, "failed").Inc()
+
+	d.recorder.Eventf(configuration, corev1.EventTypeWarning, "DeliveryFailed", "cannot deliver the %s event of %s/%s: %s", item.event.Type, item.event.Data.Namespace, item.event.Data.Name, err.Error())
+}
+
+func (d *Dispatcher) send(ctx context.Context, configuration *kamajiv1alpha1.NotificationConfiguration, event Event) error {
+	var signingKey []byte
+
+	if ref := configuration.Spec.Sink.SigningKey; ref != nil {
+		var err error
+		if signingKey, err = ref.GetContent(ctx, d.Client); err != nil {
+			return &DeliveryError{Retryable: true, err: err}
+		}
+	}
+
+	httpClient, err := HTTPClient(configuration.Spec.Sink)
+	if err != nil {
+		return &DeliveryError{err: err}
+	}
+
+	body, err := event.Marshal()
+	if err != nil {
+		return &DeliveryError{err: err}
+	}
+
+	if timeout := configuration.Spec.Retry.Timeout.Duration; timeout > 0 {
+		var cancelFn context.CancelFunc
+
+		ctx, cancelFn = context.WithTimeout(ctx, timeout)
+		defer cancelFn()
+	}
+
+	return Deliver(ctx, httpClient, configuration.Spec.Sink.URL, signingKey, body)
+}
+
+func (d *Dispatcher) SetupWithManager(mgr manager.Manager) error {
+	d.cache = mgr.GetCache()
+	d.recorder = mgr.GetEventRecorderFor("notifications")
+
+	return mgr.Add(d)
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	specVersion = "1.0"
+	contentType = "application/json"
+)
+
+// CloudEvents types of the Tenant Control Plane lifecycle events.
+const (
+	TypeStatusChanged       = "io.clastix.kamaji.tenantcontrolplane.status.changed"
+	TypeCertificatesRotated = "io.clastix.kamaji.tenantcontrolplane.certificates.rotated"
+	TypeDataStoreMigrated   = "io.clastix.kamaji.tenantcontrolplane.datastore.migrated"
+	TypeDeleted             = "io.clastix.kamaji.tenantcontrolplane.deleted"
+)
+
+var eventTypes = map[kamajiv1alpha1.NotificationEvent]string{
+	kamajiv1alpha1.NotificationEventStatusChanged:       TypeStatusChanged,
+	kamajiv1alpha1.NotificationEventCertificatesRotated: TypeCertificatesRotated,
+	kamajiv1alpha1.NotificationEventDataStoreMigrated:   TypeDataStoreMigrated,
+	kamajiv1alpha1.NotificationEventDeleted:             TypeDeleted,
+}
+
+// Event is a CloudEvent, serialized in the structured content mode.
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// Data is the payload of the Tenant Control Plane lifecycle events.
+type Data struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	// PreviousStatus and Status are reported by the status change events.
+	PreviousStatus string `json:"previousStatus,omitempty"`
+	Status         string `json:"status,omitempty"`
+	// Certificates are the names of the certificates, and of the kubeconfigs, reported by the rotation events.
+	Certificates []string `json:"certificates,omitempty"`
+	// PreviousDataStore and DataStore are reported by the DataStore migration events.
+	PreviousDataStore string `json:"previousDataStore,omitempty"`
+	DataStore         string `json:"dataStore,omitempty"`
+}
+
+func (e Event) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func newEvent(eventType string, tcp *kamajiv1alpha1.TenantControlPlane, data Data) Event {
+	data.Namespace, data.Name = tcp.GetNamespace(), tcp.GetName()
+	data.Version = tcp.Status.Kubernetes.Version.Version
+
+	return Event{
+		SpecVersion:     specVersion,
+		ID:              uuid.NewString(),
+		Source:          fmt.Sprintf("/apis/%s/namespaces/%s/tenantcontrolplanes", kamajiv1alpha1.GroupVersion.String(), tcp.GetNamespace()),
+		Type:            eventType,
+		Subject:         tcp.GetName(),
+		Time:            time.Now().UTC(),
+		DataContentType: contentType,
+		Data:            data,
+	}
+}
+
+// Diff returns the lifecycle events of the Tenant Control Plane, by comparing its previous and current state.
+func Diff(previous, current *kamajiv1alpha1.TenantControlPlane) []Event {
+	var events []Event
+
+	if status := current.Status.Kubernetes.Version.Status; status != nil {
+		previousStatus := ""
+		if previous.Status.Kubernetes.Version.Status != nil {
+			previousStatus = string(*previous.Status.Kubernetes.Version.Status)
+		}
+
+		if previousStatus != string(*status) {
+			events = append(events, newEvent(TypeStatusChanged, current, Data{PreviousStatus: previousStatus, Status: string(*status)}))
+		}
+	}
+
+	if rotated := rotatedCertificates(previous, current); len(rotated) > 0 {
+		events = append(events, newEvent(TypeCertificatesRotated, current, Data{Certificates: rotated}))
+	}
+	// The DataStore name is reported in the status once the migration has been completed.
+	if from, to := previous.Status.Storage.DataStoreName, current.Status.Storage.DataStoreName; len(from) > 0 && len(to) > 0 && from != to {
+		events = append(events, newEvent(TypeDataStoreMigrated, current, Data{PreviousDataStore: from, DataStore: to}))
+	}
+
+	return events
+}
+
+// Deleted returns the deletion event of the Tenant Control Plane.
+func Deleted(tcp *kamajiv1alpha1.TenantControlPlane) Event {
+	return newEvent(TypeDeleted, tcp, Data{})
+}
+
+// rotatedCertificates returns the names of the certificates, and of the kubeconfigs, whose checksum has been changed:
+// the ones issued for the first time are not considered rotated.
+func rotatedCertificates(previous, current *kamajiv1alpha1.TenantControlPlane) []string {
+	checksums := func(tcp *kamajiv1alpha1.TenantControlPlane) [][2]string {
+		certificates, kubeconfigs := tcp.Status.Certificates, tcp.Status.KubeConfig
+
+		return [][2]string{
+			{"ca", certificates.CA.Checksum},
+			{"apiServer", certificates.APIServer.Checksum},
+			{"apiServerKubeletClient", certificates.APIServerKubeletClient.Checksum},
+			{"frontProxyCA", certificates.FrontProxyCA.Checksum},
+			{"frontProxyClient", certificates.FrontProxyClient.Checksum},
+			{"sa", certificates.SA.Checksum},
+			{"kubeconfig/admin", kubeconfigs.Admin.Checksum},
+			{"kubeconfig/controllerManager", kubeconfigs.ControllerManager.Checksum},
+			{"kubeconfig/scheduler", kubeconfigs.Scheduler.Checksum},
+		}
+	}
+
+	var rotated []string
+
+	previousChecksums, currentChecksums := checksums(previous), checksums(current)
+	for i := range currentChecksums {
+		if previousChecksum, currentChecksum := previousChecksums[i][1], currentChecksums[i][1]; len(previousChecksum) > 0 && len(currentChecksum) > 0 && previousChecksum != currentChecksum {
+			rotated = append(rotated, currentChecksums[i][0])
+		}
+	}
+
+	return rotated
+}
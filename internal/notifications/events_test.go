@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func tenantControlPlane(status kamajiv1alpha1.KubernetesVersionStatus, dataStore, caChecksum string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"},
+	}
+	tcp.Status.Kubernetes.Version.Version = "v1.26.1"
+	tcp.Status.Kubernetes.Version.Status = &status
+	tcp.Status.Storage.DataStoreName = dataStore
+	tcp.Status.Certificates.CA.Checksum = caChecksum
+
+	return tcp
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous *kamajiv1alpha1.TenantControlPlane
+		current  *kamajiv1alpha1.TenantControlPlane
+		expected []string
+	}{
+		{
+			name:     "no changes",
+			previous: tenantControlPlane(kamajiv1alpha1.VersionReady, "default", "a"),
+			current:  tenantControlPlane(kamajiv1alpha1.VersionReady, "default", "a"),
+		},
+		{
+			name:     "status transition",
+			previous: tenantControlPlane(kamajiv1alpha1.VersionProvisioning, "default", "a"),
+			current:  tenantControlPlane(kamajiv1alpha1.VersionReady, "default", "a"),
+			expected: []string{TypeStatusChanged},
+		},
+		{
+			name:     "CA issued",
+			previous: tenantControlPlane(kamajiv1alpha1.VersionProvisioning, "default", ""),
+			current:  tenantControlPlane(kamajiv1alpha1.VersionProvisioning, "default", "a"),
+		},
+		{
+			name:     "CA rotated",
+			previous: tenantControlPlane(kamajiv1alpha1.VersionCARotating, "default", "a"),
+			current:  tenantControlPlane(kamajiv1alpha1.VersionCARotating, "default", "b"),
+			expected: []string{TypeCertificatesRotated},
+		},
+		{
+			name:     "DataStore migrated",
+			previous: tenantControlPlane(kamajiv1alpha1.VersionMigrating, "default", "a"),
+			current:  tenantControlPlane(kamajiv1alpha1.VersionReady, "gold", "a"),
+			expected: []string{TypeStatusChanged, TypeDataStoreMigrated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var types []string
+
+			for _, event := range Diff(tt.previous, tt.current) {
+				if event.Data.Namespace != "default" || event.Data.Name != "tenant-00" || event.Subject != "tenant-00" {
+					t.Fatalf("unexpected event subject %v", event)
+				}
+
+				types = append(types, event.Type)
+			}
+
+			if !reflect.DeepEqual(types, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, types)
+			}
+		})
+	}
+}
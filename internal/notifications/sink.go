@@ -0,0 +1,143 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	// SignatureHeader is the header reporting the HMAC-SHA256 signature of the request body, when a signing key is configured.
+	SignatureHeader = "X-Kamaji-Signature"
+	// structuredContentType is the content type of the CloudEvents sent in the structured content mode.
+	structuredContentType = "application/cloudevents+json; charset=utf-8"
+)
+
+// DeliveryError is returned upon a failed delivery, reporting if it can be retried.
+type DeliveryError struct {
+	Retryable bool
+	err       error
+}
+
+func (e *DeliveryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.err
+}
+
+// Matches returns true if the event of the given Tenant Control Plane must be notified according to the configuration filters.
+func Matches(spec kamajiv1alpha1.NotificationConfigurationSpec, tcp *kamajiv1alpha1.TenantControlPlane, event Event) (bool, error) {
+	if len(spec.Events) > 0 && !containsEvent(spec.Events, event.Type) {
+		return false, nil
+	}
+
+	if len(spec.Namespaces) > 0 && !containsString(spec.Namespaces, tcp.GetNamespace()) {
+		return false, nil
+	}
+
+	if spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse the Tenant Control Plane selector: %w", err)
+		}
+
+		if !selector.Matches(labels.Set(tcp.GetLabels())) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func containsEvent(events []kamajiv1alpha1.NotificationEvent, eventType string) bool {
+	for _, event := range events {
+		if eventTypes[event] == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sign returns the HMAC-SHA256 signature of the given body, hex encoded and prefixed by the algorithm.
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// HTTPClient returns the client used to deliver the CloudEvents to the sink, trusting its CA bundle, if any.
+func HTTPClient(sink kamajiv1alpha1.NotificationSink) (*http.Client, error) {
+	if len(sink.CABundle) == 0 {
+		return &http.Client{}, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(sink.CABundle) {
+		return nil, fmt.Errorf("the sink CA bundle doesn't contain any valid certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// Deliver sends the CloudEvent body to the sink: the network errors, and the 429 and 5xx responses, can be retried.
+func Deliver(ctx context.Context, client *http.Client, url string, signingKey []byte, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &DeliveryError{err: err}
+	}
+
+	request.Header.Set("Content-Type", structuredContentType)
+
+	if len(signingKey) > 0 {
+		request.Header.Set(SignatureHeader, Sign(signingKey, body))
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return &DeliveryError{Retryable: true, err: err}
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	switch code := response.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+	case code == http.StatusTooManyRequests, code >= 500:
+		return &DeliveryError{Retryable: true, err: fmt.Errorf("the sink responded with status code %d", code)}
+	default:
+		return &DeliveryError{err: fmt.Errorf("the sink rejected the event with status code %d", code)}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDeliver(t *testing.T) {
+	key := []byte("secret")
+	event := Deleted(tenantControlPlane(kamajiv1alpha1.VersionReady, "default", "a"))
+
+	body, err := event.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var received Event
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get(SignatureHeader) != Sign(key, payload) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if err := json.Unmarshal(payload, &received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	if err = Deliver(context.Background(), server.Client(), server.URL, key, body); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if received.Type != TypeDeleted || received.SpecVersion != specVersion || received.ID != event.ID {
+		t.Fatalf("unexpected received event %v", received)
+	}
+
+	var deliveryErr *DeliveryError
+	if err = Deliver(context.Background(), server.Client(), server.URL, []byte("wrong"), body); !errors.As(err, &deliveryErr) || deliveryErr.Retryable {
+		t.Fatalf("expected a non retryable error, got %v", err)
+	}
+}
+
+func TestDeliverRetryable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var deliveryErr *DeliveryError
+	if err := Deliver(context.Background(), server.Client(), server.URL, nil, []byte("{}")); !errors.As(err, &deliveryErr) || !deliveryErr.Retryable {
+		t.Fatalf("expected a retryable error, got %v", err)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tcp := tenantControlPlane(kamajiv1alpha1.VersionReady, "default", "a")
+	tcp.SetLabels(map[string]string{"tier": "gold"})
+
+	event := Deleted(tcp)
+
+	tests := []struct {
+		name     string
+		spec     kamajiv1alpha1.NotificationConfigurationSpec
+		expected bool
+	}{
+		{
+			name:     "no filters",
+			expected: true,
+		},
+		{
+			name:     "event not selected",
+			spec:     kamajiv1alpha1.NotificationConfigurationSpec{Events: []kamajiv1alpha1.NotificationEvent{kamajiv1alpha1.NotificationEventStatusChanged}},
+			expected: false,
+		},
+		{
+			name:     "namespace not selected",
+			spec:     kamajiv1alpha1.NotificationConfigurationSpec{Namespaces: []string{"kube-system"}},
+			expected: false,
+		},
+		{
+			name: "matching labels",
+			spec: kamajiv1alpha1.NotificationConfigurationSpec{
+				Events:     []kamajiv1alpha1.NotificationEvent{kamajiv1alpha1.NotificationEventDeleted},
+				Namespaces: []string{"default"},
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			},
+			expected: true,
+		},
+		{
+			name:     "not matching labels",
+			spec:     kamajiv1alpha1.NotificationConfigurationSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "bronze"}}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Matches(tt.spec, tcp, event)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if ok != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package priorityqueue
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// Class is the priority class of a reconciliation request.
+type Class string
+
+const (
+	ClassHigh   Class = "high"
+	ClassNormal Class = "normal"
+	ClassLow    Class = "low"
+)
+
+// Classes are the supported priority classes, sorted by dispatching order.
+var Classes = []Class{ClassHigh, ClassNormal, ClassLow}
+
+// ParseClass returns the priority class matching the given value, defaulting to the normal one when empty.
+func ParseClass(value string) (Class, error) {
+	if len(value) == 0 {
+		return ClassNormal, nil
+	}
+
+	for _, class := range Classes {
+		if string(class) == value {
+			return class, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported priority class %q, must be one of %v", value, Classes)
+}
+
+// ClassFor returns the priority class declared by the object using the priority annotation:
+// missing, or invalid values, fall back to the normal priority class.
+func ClassFor(object client.Object) Class {
+	class, err := ParseClass(object.GetAnnotations()[constants.Priority])
+	if err != nil {
+		return ClassNormal
+	}
+
+	return class
+}
+
+// Higher returns true when the given class is dispatched before the other one.
+func (in Class) Higher(other Class) bool {
+	return in.index() < other.index()
+}
+
+func (in Class) index() int {
+	for i, class := range Classes {
+		if class == in {
+			return i
+		}
+	}
+
+	return len(Classes)
+}
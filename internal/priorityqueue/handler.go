@@ -0,0 +1,83 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package priorityqueue
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// Handler wraps the given event handler, enqueuing its reconciliation requests in the priority queue
+// rather than in the controller workqueue.
+func (q *Queue) Handler(h handler.EventHandler) handler.EventHandler {
+	return &eventHandler{queue: q, handler: h}
+}
+
+// Reconciler wraps the given reconciler, triggering the dispatch of the pending requests
+// as soon as a worker picks up a request from the workqueue.
+func (q *Queue) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return &reconciler{queue: q, reconciler: r}
+}
+
+type eventHandler struct {
+	queue   *Queue
+	handler handler.EventHandler
+}
+
+func (e *eventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.handler.Create(evt, &adapter{RateLimitingInterface: q, queue: e.queue})
+}
+
+func (e *eventHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.handler.Update(evt, &adapter{RateLimitingInterface: q, queue: e.queue})
+}
+
+func (e *eventHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.handler.Delete(evt, &adapter{RateLimitingInterface: q, queue: e.queue})
+}
+
+func (e *eventHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.handler.Generic(evt, &adapter{RateLimitingInterface: q, queue: e.queue})
+}
+
+// InjectFunc lets the manager inject its dependencies into the wrapped handler, such as the scheme, and the REST mapper.
+func (e *eventHandler) InjectFunc(f inject.Func) error {
+	return f(e.handler)
+}
+
+// adapter is the workqueue handed over to the wrapped event handlers:
+// the additions are redirected to the priority queue, the other calls are served by the controller workqueue.
+type adapter struct {
+	workqueue.RateLimitingInterface
+	queue *Queue
+}
+
+func (a *adapter) Add(item interface{}) {
+	a.queue.push(a.RateLimitingInterface, item, false)
+}
+
+func (a *adapter) AddRateLimited(item interface{}) {
+	a.queue.push(a.RateLimitingInterface, item, true)
+}
+
+type reconciler struct {
+	queue      *Queue
+	reconciler reconcile.Reconciler
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	r.queue.notify()
+
+	return r.reconciler.Reconcile(ctx, request)
+}
+
+// InjectFunc lets the manager inject its dependencies into the wrapped reconciler, such as the client.
+func (r *reconciler) InjectFunc(f inject.Func) error {
+	return f(r.reconciler)
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package priorityqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	pendingRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_reconcile_queue_pending_requests",
+		Help: "Number of reconciliation requests waiting to be dispatched to the controller workers, per priority class.",
+	}, []string{"controller", "priority"})
+	dispatchedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kamaji_reconcile_queue_dispatched_requests_total",
+		Help: "Total number of reconciliation requests dispatched to the controller workers, per priority class.",
+	}, []string{"controller", "priority"})
+	waitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kamaji_reconcile_queue_wait_duration_seconds",
+		Help:    "Time spent by the reconciliation requests before being dispatched to the controller workers, per priority class.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 16),
+	}, []string{"controller", "priority"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(pendingRequests, dispatchedRequests, waitDuration)
+}
+
+// dispatchInterval is the period of the dispatching attempts, besides the ones triggered by the queue events.
+const dispatchInterval = time.Second
+
+// PriorityFn returns the priority class of the given reconciliation request.
+type PriorityFn func(ctx context.Context, request reconcile.Request) Class
+
+type pendingRequest struct {
+	request     reconcile.Request
+	rateLimited bool
+	enqueuedAt  time.Time
+}
+
+// Queue holds the reconciliation requests enqueued by the event handlers of a controller,
+// and dispatches them to its workqueue according to their priority class: the requests are handed over
+// only when the workqueue has room, letting the higher priority ones skip the lower priority backlog.
+// The requeue of the failed reconciliations, and the delayed ones, are directly served by the workqueue.
+type Queue struct {
+	name     string
+	capacity int
+	priority PriorityFn
+
+	lock    sync.Mutex
+	target  workqueue.RateLimitingInterface
+	pending map[Class][]pendingRequest
+	classes map[reconcile.Request]Class
+	wake    chan struct{}
+}
+
+// New returns a Queue for the given controller: capacity is the number of requests allowed to wait
+// in the workqueue, and it should match the number of the controller workers.
+func New(name string, capacity int, priority PriorityFn) *Queue {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Queue{
+		name:     name,
+		capacity: capacity,
+		priority: priority,
+		pending:  make(map[Class][]pendingRequest),
+		classes:  make(map[reconcile.Request]Class),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Start dispatches the pending requests until the context is cancelled, implementing the manager.Runnable interface.
+func (q *Queue) Start(ctx context.Context) error {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-q.wake:
+		}
+
+		q.dispatch()
+	}
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) push(target workqueue.RateLimitingInterface, item interface{}, rateLimited bool) {
+	request, ok := item.(reconcile.Request)
+	if !ok {
+		target.Add(item)
+
+		return
+	}
+
+	class := q.priority(context.Background(), request)
+
+	q.lock.Lock()
+	defer q.notify()
+	defer q.lock.Unlock()
+
+	q.target = target
+
+	if current, ok := q.classes[request]; ok {
+		// The request is already pending: it's promoted when the new priority class is higher.
+		if !class.Higher(current) {
+			return
+		}
+
+		q.remove(current, request)
+	}
+
+	q.pending[class] = append(q.pending[class], pendingRequest{request: request, rateLimited: rateLimited, enqueuedAt: time.Now()})
+	q.classes[request] = class
+
+	pendingRequests.WithLabelValues(q.name, string(class)).Set(float64(len(q.pending[class])))
+}
+
+func (q *Queue) remove(class Class, request reconcile.Request) {
+	for i, pending := range q.pending[class] {
+		if pending.request == request {
+			q.pending[class] = append(q.pending[class][:i], q.pending[class][i+1:]...)
+
+			break
+		}
+	}
+
+	delete(q.classes, request)
+
+	pendingRequests.WithLabelValues(q.name, string(class)).Set(float64(len(q.pending[class])))
+}
+
+func (q *Queue) dispatch() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.target == nil {
+		return
+	}
+
+	for _, class := range Classes {
+		for len(q.pending[class]) > 0 {
+			if q.target.Len() >= q.capacity {
+				return
+			}
+
+			pending := q.pending[class][0]
+			q.remove(class, pending.request)
+
+			if pending.rateLimited {
+				q.target.AddRateLimited(pending.request)
+			} else {
+				q.target.Add(pending.request)
+			}
+
+			dispatchedRequests.WithLabelValues(q.name, string(class)).Inc()
+			waitDuration.WithLabelValues(q.name, string(class)).Observe(time.Since(pending.enqueuedAt).Seconds())
+		}
+	}
+}
+
+// Len returns the number of pending requests.
+func (q *Queue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.classes)
+}
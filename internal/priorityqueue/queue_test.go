@@ -0,0 +1,147 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package priorityqueue
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+func request(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name}}
+}
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Class
+		wantErr  bool
+	}{
+		{value: "", expected: ClassNormal},
+		{value: "high", expected: ClassHigh},
+		{value: "normal", expected: ClassNormal},
+		{value: "low", expected: ClassLow},
+		{value: "urgent", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			class, err := ParseClass(tt.value)
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatalf("expected error, got %s", class)
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case class != tt.expected:
+				t.Fatalf("expected %s, got %s", tt.expected, class)
+			}
+		})
+	}
+}
+
+func TestQueueDispatch(t *testing.T) {
+	classes := map[string]Class{"prod": ClassHigh, "dev-00": ClassLow, "dev-01": ClassLow, "staging": ClassNormal}
+
+	queue := New("test", 1, func(_ context.Context, request reconcile.Request) Class {
+		return classes[request.Name]
+	})
+
+	target := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer target.ShutDown()
+
+	h := queue.Handler(&handler.EnqueueRequestForObject{})
+
+	for _, name := range []string{"dev-00", "dev-01", "staging", "prod"} {
+		h.Generic(event.GenericEvent{Object: &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}}, target)
+	}
+
+	if target.Len() != 0 || queue.Len() != 4 {
+		t.Fatalf("expected the requests to be pending, got %d in the workqueue and %d pending", target.Len(), queue.Len())
+	}
+
+	for _, expected := range []string{"prod", "staging", "dev-00", "dev-01"} {
+		queue.dispatch()
+		// The capacity is preventing more requests to be handed over until a worker picks up one.
+		if target.Len() != 1 {
+			t.Fatalf("expected a single request in the workqueue, got %d", target.Len())
+		}
+
+		item, _ := target.Get()
+		if actual := item.(reconcile.Request); actual != request(expected) { //nolint:forcetypeassert
+			t.Fatalf("expected %s, got %s", expected, actual.Name)
+		}
+
+		target.Done(item)
+	}
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no pending requests, got %d", queue.Len())
+	}
+}
+
+func TestQueuePromotion(t *testing.T) {
+	classes := map[string]Class{"tenant-00": ClassLow, "tenant-01": ClassNormal}
+
+	queue := New("test", 1, func(_ context.Context, request reconcile.Request) Class {
+		return classes[request.Name]
+	})
+
+	target := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer target.ShutDown()
+
+	adapter := &adapter{RateLimitingInterface: target, queue: queue}
+	adapter.Add(request("tenant-00"))
+	adapter.Add(request("tenant-01"))
+	// The Tenant Control Plane has been annotated in the meanwhile, and it's enqueued again.
+	classes["tenant-00"] = ClassHigh
+	adapter.Add(request("tenant-00"))
+	// A lower priority doesn't demote an already pending request.
+	classes["tenant-01"] = ClassLow
+	adapter.Add(request("tenant-01"))
+
+	if queue.Len() != 2 {
+		t.Fatalf("expected the requests to be deduplicated, got %d pending", queue.Len())
+	}
+
+	queue.dispatch()
+
+	if item, _ := target.Get(); item.(reconcile.Request) != request("tenant-00") { //nolint:forcetypeassert
+		t.Fatalf("expected the promoted request to be dispatched first, got %v", item)
+	}
+
+	if pending := queue.pending[ClassNormal]; len(pending) != 1 || pending[0].request != request("tenant-01") {
+		t.Fatalf("expected the request to retain its priority class, got %v", pending)
+	}
+}
+
+func TestClassFor(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+
+	if class := ClassFor(tcp); class != ClassNormal {
+		t.Fatalf("expected the normal class by default, got %s", class)
+	}
+
+	tcp.SetAnnotations(map[string]string{constants.Priority: "high"})
+
+	if class := ClassFor(tcp); class != ClassHigh {
+		t.Fatalf("expected the high class, got %s", class)
+	}
+
+	tcp.SetAnnotations(map[string]string{constants.Priority: "foo"})
+
+	if class := ClassFor(tcp); class != ClassNormal {
+		t.Fatalf("expected the invalid class to fall back to the normal one, got %s", class)
+	}
+}
@@ -6,6 +6,7 @@ package addons
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/utils"
@@ -26,6 +28,7 @@ import (
 
 type CoreDNS struct {
 	Client client.Client
+	Images images.Policy
 
 	deployment         *appsv1.Deployment
 	configMap          *corev1.ConfigMap
@@ -218,6 +221,22 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 		return errors.Wrap(err, "unable to decode Deployment manifest")
 	}
 
+	c.deployment.Spec.Template.Spec.Containers[0].Image = c.Images.Image(tcp, c.deployment.Spec.Template.Spec.Containers[0].Image)
+	// When exposed through the SNI proxy, the in-cluster clients connecting by IP address wouldn't send any server name:
+	// CoreDNS must reach the API Server using the Tenant Control Plane hostname.
+	if sniProxy := tcp.Spec.ControlPlane.SNIProxy; sniProxy != nil {
+		c.deployment.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+			{
+				Name:  "KUBERNETES_SERVICE_HOST",
+				Value: sniProxy.Hostname,
+			},
+			{
+				Name:  "KUBERNETES_SERVICE_PORT",
+				Value: fmt.Sprintf("%d", sniProxy.Port),
+			},
+		}
+	}
+
 	if err = utilities.DecodeFromYAML(string(parts[2]), c.configMap); err != nil {
 		return errors.Wrap(err, "unable to decode ConfigMap manifest")
 	}
@@ -289,6 +308,7 @@ func (c *CoreDNS) mutateDeployment(ctx context.Context, tenantClient client.Clie
 		d.Spec.Template.Spec.Containers[0].Name = c.deployment.Spec.Template.Spec.Containers[0].Name
 		d.Spec.Template.Spec.Containers[0].Image = c.deployment.Spec.Template.Spec.Containers[0].Image
 		d.Spec.Template.Spec.Containers[0].Args = c.deployment.Spec.Template.Spec.Containers[0].Args
+		d.Spec.Template.Spec.Containers[0].Env = c.deployment.Spec.Template.Spec.Containers[0].Env
 		if len(d.Spec.Template.Spec.Containers[0].Ports) != 3 {
 			d.Spec.Template.Spec.Containers[0].Ports = make([]corev1.ContainerPort, 3)
 		}
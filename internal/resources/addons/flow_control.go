@@ -0,0 +1,297 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	flowcontrolv1beta3 "k8s.io/api/flowcontrol/v1beta3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// FlowControlComponent is the value of the component label of the resources managed by the flow control addon.
+	FlowControlComponent = "flow-control"
+
+	flowControlNamePrefix = "kamaji-"
+)
+
+// DefaultFlowControlPriorityLevels are the priority levels seeded in the Tenant Cluster when none is declared:
+// the kubelets, and the control plane components managed by Kamaji, are taking precedence over the Kubernetes
+// default FlowSchemas, avoiding the tenant workloads to starve them.
+var DefaultFlowControlPriorityLevels = []kamajiv1alpha1.FlowControlPriorityLevel{
+	{
+		Name:                     "nodes",
+		NominalConcurrencyShares: 40,
+		MatchingPrecedence:       400,
+		Groups:                   []string{"system:nodes"},
+	},
+	{
+		Name:                     "control-plane",
+		NominalConcurrencyShares: 40,
+		MatchingPrecedence:       450,
+		Users: []string{
+			"system:kube-controller-manager",
+			"system:kube-scheduler",
+			"system:serviceaccount:kube-system:konnectivity-agent",
+			"system:serviceaccount:kube-system:kube-proxy",
+			"system:serviceaccount:kube-system:coredns",
+		},
+	},
+}
+
+// minVerFlowControl is the first Kubernetes version serving the flowcontrol.apiserver.k8s.io/v1beta3 API.
+var minVerFlowControl = semver.MustParse("1.26.0")
+
+// FlowControlSupported returns true if the given Kubernetes version serves the API used by the flow control addon.
+func FlowControlSupported(version string) bool {
+	parsedVersion, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false
+	}
+
+	return parsedVersion.GTE(minVerFlowControl)
+}
+
+type FlowControl struct {
+	Client client.Client
+
+	priorityLevels []kamajiv1alpha1.FlowControlPriorityLevel
+}
+
+func (f *FlowControl) Define(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	f.priorityLevels = nil
+
+	if tcp.Spec.Addons.FlowControl == nil {
+		return nil
+	}
+
+	f.priorityLevels = tcp.Spec.Addons.FlowControl.PriorityLevels
+	if len(f.priorityLevels) == 0 {
+		f.priorityLevels = DefaultFlowControlPriorityLevels
+	}
+
+	return nil
+}
+
+func (f *FlowControl) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.FlowControl == nil
+}
+
+func (f *FlowControl) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// Nothing to clean up if the addon has never been enabled, also avoiding to query the Tenant Clusters
+	// which are not serving the flow control API version.
+	if !tcp.Status.Addons.FlowControl.Enabled {
+		return false, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", "kubeadm_addons", "addon", f.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	return f.prune(ctx, tenantClient, sets.NewString())
+}
+
+func (f *FlowControl) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", f.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	var operationResult controllerutil.OperationResult
+
+	reconciliationResult := controllerutil.OperationResultNone
+	desired := sets.NewString()
+
+	for _, level := range f.priorityLevels {
+		name := flowControlNamePrefix + level.Name
+		desired.Insert(name)
+		// PriorityLevelConfiguration
+		operationResult, err = f.mutatePriorityLevelConfiguration(ctx, tenantClient, name, level)
+		if err != nil {
+			logger.Error(err, "PriorityLevelConfiguration reconciliation failed", "name", name)
+
+			return controllerutil.OperationResultNone, err
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+		// FlowSchema
+		operationResult, err = f.mutateFlowSchema(ctx, tenantClient, name, level)
+		if err != nil {
+			logger.Error(err, "FlowSchema reconciliation failed", "name", name)
+
+			return controllerutil.OperationResultNone, err
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+	}
+	// Removing the priority levels which are no more declared
+	pruned, err := f.prune(ctx, tenantClient, desired)
+	if err != nil {
+		logger.Error(err, "pruning of the undeclared priority levels failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if pruned {
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, controllerutil.OperationResultUpdated)
+	}
+
+	return reconciliationResult, nil
+}
+
+func (f *FlowControl) GetName() string {
+	return "flow-control"
+}
+
+func (f *FlowControl) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.FlowControl != nil && !tcp.Status.Addons.FlowControl.Enabled
+}
+
+func (f *FlowControl) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.FlowControl.Enabled = tcp.Spec.Addons.FlowControl != nil
+	tcp.Status.Addons.FlowControl.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+func (f *FlowControl) labels() map[string]string {
+	return map[string]string{
+		constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+		constants.ControlPlaneLabelResource: FlowControlComponent,
+	}
+}
+
+func (f *FlowControl) mutatePriorityLevelConfiguration(ctx context.Context, tenantClient client.Client, name string, level kamajiv1alpha1.FlowControlPriorityLevel) (controllerutil.OperationResult, error) {
+	plc := &flowcontrolv1beta3.PriorityLevelConfiguration{}
+	plc.SetName(name)
+
+	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, plc, func() error {
+		plc.SetLabels(utilities.MergeMaps(plc.GetLabels(), f.labels()))
+
+		plc.Spec.Type = flowcontrolv1beta3.PriorityLevelEnablementLimited
+
+		if plc.Spec.Limited == nil {
+			plc.Spec.Limited = &flowcontrolv1beta3.LimitedPriorityLevelConfiguration{}
+		}
+		// The remaining fields, such as the lendable percentage, are left to the API Server defaults.
+		plc.Spec.Limited.NominalConcurrencyShares = level.NominalConcurrencyShares
+		plc.Spec.Limited.LimitResponse = flowcontrolv1beta3.LimitResponse{
+			Type: flowcontrolv1beta3.LimitResponseTypeQueue,
+			Queuing: &flowcontrolv1beta3.QueuingConfiguration{
+				Queues:           64,
+				HandSize:         6,
+				QueueLengthLimit: 50,
+			},
+		}
+
+		return nil
+	})
+}
+
+func (f *FlowControl) mutateFlowSchema(ctx context.Context, tenantClient client.Client, name string, level kamajiv1alpha1.FlowControlPriorityLevel) (controllerutil.OperationResult, error) {
+	subjects := make([]flowcontrolv1beta3.Subject, 0, len(level.Users)+len(level.Groups))
+
+	for _, user := range level.Users {
+		subjects = append(subjects, flowcontrolv1beta3.Subject{Kind: flowcontrolv1beta3.SubjectKindUser, User: &flowcontrolv1beta3.UserSubject{Name: user}})
+	}
+
+	for _, group := range level.Groups {
+		subjects = append(subjects, flowcontrolv1beta3.Subject{Kind: flowcontrolv1beta3.SubjectKindGroup, Group: &flowcontrolv1beta3.GroupSubject{Name: group}})
+	}
+
+	fs := &flowcontrolv1beta3.FlowSchema{}
+	fs.SetName(name)
+
+	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, fs, func() error {
+		fs.SetLabels(utilities.MergeMaps(fs.GetLabels(), f.labels()))
+
+		fs.Spec.PriorityLevelConfiguration = flowcontrolv1beta3.PriorityLevelConfigurationReference{Name: name}
+		fs.Spec.MatchingPrecedence = level.MatchingPrecedence
+		fs.Spec.DistinguisherMethod = &flowcontrolv1beta3.FlowDistinguisherMethod{Type: flowcontrolv1beta3.FlowDistinguisherMethodByUserType}
+		fs.Spec.Rules = []flowcontrolv1beta3.PolicyRulesWithSubjects{
+			{
+				Subjects: subjects,
+				ResourceRules: []flowcontrolv1beta3.ResourcePolicyRule{
+					{
+						Verbs:        []string{flowcontrolv1beta3.VerbAll},
+						APIGroups:    []string{flowcontrolv1beta3.APIGroupAll},
+						Resources:    []string{flowcontrolv1beta3.ResourceAll},
+						ClusterScope: true,
+						Namespaces:   []string{flowcontrolv1beta3.NamespaceEvery},
+					},
+				},
+				NonResourceRules: []flowcontrolv1beta3.NonResourcePolicyRule{
+					{
+						Verbs:           []string{flowcontrolv1beta3.VerbAll},
+						NonResourceURLs: []string{flowcontrolv1beta3.NonResourceAll},
+					},
+				},
+			},
+		}
+
+		return nil
+	})
+}
+
+// prune deletes the FlowSchema and PriorityLevelConfiguration resources managed by Kamaji not matching the desired names,
+// returning true when any has been deleted.
+func (f *FlowControl) prune(ctx context.Context, tenantClient client.Client, desired sets.String) (bool, error) {
+	var deleted bool
+
+	fsList := &flowcontrolv1beta3.FlowSchemaList{}
+	if err := tenantClient.List(ctx, fsList, client.MatchingLabels(f.labels())); err != nil {
+		return false, fmt.Errorf("cannot list FlowSchema resources: %w", err)
+	}
+
+	for i := range fsList.Items {
+		if desired.Has(fsList.Items[i].GetName()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &fsList.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	plcList := &flowcontrolv1beta3.PriorityLevelConfigurationList{}
+	if err := tenantClient.List(ctx, plcList, client.MatchingLabels(f.labels())); err != nil {
+		return false, fmt.Errorf("cannot list PriorityLevelConfiguration resources: %w", err)
+	}
+
+	for i := range plcList.Items {
+		if desired.Has(plcList.Items[i].GetName()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &plcList.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/utils"
@@ -27,6 +28,7 @@ import (
 
 type KubeProxy struct {
 	Client client.Client
+	Images images.Policy
 
 	serviceAccount     *corev1.ServiceAccount
 	clusterRoleBinding *rbacv1.ClusterRoleBinding
@@ -400,5 +402,7 @@ func (k *KubeProxy) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Ten
 		return errors.Wrap(err, "unable to decode DaemonSet manifest")
 	}
 
+	k.daemonSet.Spec.Template.Spec.Containers[0].Image = k.Images.Image(tcp, k.daemonSet.Spec.Template.Spec.Containers[0].Image)
+
 	return nil
 }
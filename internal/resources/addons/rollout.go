@@ -0,0 +1,121 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// DeploymentRollout returns the rollout status of the given addon Deployment,
+// following the same logic of the kubectl rollout status command.
+func DeploymentRollout(deployment *appsv1.Deployment) *kamajiv1alpha1.AddonRolloutStatus {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	status := &kamajiv1alpha1.AddonRolloutStatus{
+		DesiredReplicas:   desired,
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+	}
+
+	switch {
+	case deployment.Generation > deployment.Status.ObservedGeneration:
+		status.Message = "waiting for the Deployment spec update to be observed"
+	case deployment.Status.UpdatedReplicas < desired:
+		status.Message = fmt.Sprintf("%d out of %d new replicas have been updated", deployment.Status.UpdatedReplicas, desired)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	default:
+		status.Ready = true
+	}
+
+	return status
+}
+
+// DaemonSetRollout returns the rollout status of the given addon DaemonSet,
+// following the same logic of the kubectl rollout status command.
+func DaemonSetRollout(daemonSet *appsv1.DaemonSet) *kamajiv1alpha1.AddonRolloutStatus {
+	status := &kamajiv1alpha1.AddonRolloutStatus{
+		DesiredReplicas:   daemonSet.Status.DesiredNumberScheduled,
+		UpdatedReplicas:   daemonSet.Status.UpdatedNumberScheduled,
+		AvailableReplicas: daemonSet.Status.NumberAvailable,
+	}
+
+	switch {
+	case daemonSet.Generation > daemonSet.Status.ObservedGeneration:
+		status.Message = "waiting for the DaemonSet spec update to be observed"
+	case daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled:
+		status.Message = fmt.Sprintf("%d out of %d new pods have been updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	case daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled:
+		status.Message = fmt.Sprintf("%d of %d updated pods are available", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	default:
+		status.Ready = true
+	}
+
+	return status
+}
+
+// ReadinessCondition returns the AddonsReady condition of the Tenant Control Plane,
+// according to the rollout status of its required addons.
+func ReadinessCondition(tcp *kamajiv1alpha1.TenantControlPlane, rollout kamajiv1alpha1.AddonsRolloutStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeAddonsReady,
+		ObservedGeneration: tcp.GetGeneration(),
+	}
+
+	required := tcp.RequiredAddons()
+	if len(required) == 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kamajiv1alpha1.AddonsReadyReasonNotRequired
+		condition.Message = "the readiness policy doesn't require any enabled addon"
+
+		return condition
+	}
+
+	var pending []string
+
+	for _, addon := range required {
+		var status *kamajiv1alpha1.AddonRolloutStatus
+
+		switch addon {
+		case kamajiv1alpha1.AddonCoreDNS:
+			status = rollout.CoreDNS
+		case kamajiv1alpha1.AddonKubeProxy:
+			status = rollout.KubeProxy
+		case kamajiv1alpha1.AddonKonnectivity:
+			status = rollout.Konnectivity
+		}
+
+		switch {
+		case status == nil:
+			pending = append(pending, fmt.Sprintf("%s: rollout status not yet observed", addon))
+		case !status.Ready:
+			pending = append(pending, fmt.Sprintf("%s: %s", addon, status.Message))
+		}
+	}
+
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kamajiv1alpha1.AddonsReadyReasonProgressing
+		condition.Message = strings.Join(pending, ", ")
+
+		return condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = kamajiv1alpha1.AddonsReadyReasonRolledOut
+	condition.Message = "the required addons have been rolled out"
+
+	return condition
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDeploymentRollout(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   appsv1.DeploymentStatus
+		expected bool
+	}{
+		{
+			name:   "spec update not observed",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		{
+			name:   "replicas not updated",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 3},
+		},
+		{
+			name:   "old replicas pending termination",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 3},
+		},
+		{
+			name:   "updated replicas not available",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+		{
+			name:     "rolled out",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+				Status:     tt.status,
+			}
+
+			status := DeploymentRollout(deployment)
+			if status.Ready != tt.expected {
+				t.Fatalf("expected ready %t, got %t: %s", tt.expected, status.Ready, status.Message)
+			}
+
+			if !status.Ready && len(status.Message) == 0 {
+				t.Fatal("expected a message for a not ready Deployment")
+			}
+		})
+	}
+}
+
+func TestDaemonSetRollout(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   appsv1.DaemonSetStatus
+		expected bool
+	}{
+		{
+			name:     "no nodes",
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1},
+			expected: true,
+		},
+		{
+			name:   "pods not updated",
+			status: appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+		},
+		{
+			name:   "pods not available",
+			status: appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2},
+		},
+		{
+			name:     "rolled out",
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Generation: 1}, Status: tt.status}
+
+			if status := DaemonSetRollout(daemonSet); status.Ready != tt.expected {
+				t.Fatalf("expected ready %t, got %t: %s", tt.expected, status.Ready, status.Message)
+			}
+		})
+	}
+}
+
+func TestReadinessCondition(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Spec.Addons.CoreDNS = &kamajiv1alpha1.AddonSpec{}
+	tcp.Spec.Addons.KubeProxy = &kamajiv1alpha1.AddonSpec{}
+
+	rollout := kamajiv1alpha1.AddonsRolloutStatus{
+		CoreDNS:   &kamajiv1alpha1.AddonRolloutStatus{Ready: true},
+		KubeProxy: &kamajiv1alpha1.AddonRolloutStatus{Message: "1 of 2 updated pods are available"},
+	}
+
+	if condition := ReadinessCondition(tcp, rollout); condition.Status != metav1.ConditionTrue || condition.Reason != kamajiv1alpha1.AddonsReadyReasonNotRequired {
+		t.Fatalf("unexpected condition without readiness policy: %v", condition)
+	}
+
+	tcp.Spec.Addons.ReadinessPolicy = &kamajiv1alpha1.AddonsReadinessPolicy{Required: []kamajiv1alpha1.AddonName{kamajiv1alpha1.AddonCoreDNS, kamajiv1alpha1.AddonKonnectivity}}
+
+	if condition := ReadinessCondition(tcp, rollout); condition.Status != metav1.ConditionTrue || condition.Reason != kamajiv1alpha1.AddonsReadyReasonRolledOut {
+		t.Fatalf("unexpected condition with the required addons rolled out: %v", condition)
+	}
+
+	tcp.Spec.Addons.ReadinessPolicy.Required = append(tcp.Spec.Addons.ReadinessPolicy.Required, kamajiv1alpha1.AddonKubeProxy)
+
+	if condition := ReadinessCondition(tcp, rollout); condition.Status != metav1.ConditionFalse || condition.Message != "KubeProxy: 1 of 2 updated pods are available" {
+		t.Fatalf("unexpected condition with a required addon progressing: %v", condition)
+	}
+}
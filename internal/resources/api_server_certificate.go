@@ -100,6 +100,7 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				constants.ControllerLabelResource: "x509",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
 			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
@@ -107,6 +108,13 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 			return err
 		}
 
+		config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot retrieve kubeadm configuration")
+
+			return err
+		}
+
 		if checksum := tenantControlPlane.Status.Certificates.APIServer.Checksum; len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) || len(r.resource.UID) > 0 {
 			isCAValid, err := crypto.VerifyCertificate(r.resource.Data[kubeadmconstants.APIServerCertName], secretCA.Data[kubeadmconstants.CACertName], x509.ExtKeyUsageServerAuth)
 			if err != nil {
@@ -121,16 +129,16 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
 
-			if isCAValid && isCertValid {
-				return nil
+			// When exposed through the SNI proxy, the API Server certificate must be generated again
+			// if it's not yet valid for the SNI hostnames, e.g. upon the enablement of the SNI proxy.
+			isSANValid, err := r.hasExpectedSANs(tenantControlPlane)
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s certificate SANs verification failed: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
-		}
 
-		config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
-		if err != nil {
-			logger.Error(err, "cannot retrieve kubeadm configuration")
-
-			return err
+			if isCAValid && isCertValid && isSANValid {
+				return nil
+			}
 		}
 
 		ca := kubeadm.CertificatePrivateKeyPair{
@@ -155,3 +163,19 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 		return nil
 	}
 }
+
+// hasExpectedSANs checks the certificate is valid for the SNI proxy hostnames: the other SANs are not verified
+// to prevent the rotation of the certificates of the existing Tenant Control Planes upon the upgrade.
+func (r *APIServerCertificate) hasExpectedSANs(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	sniProxy := tenantControlPlane.Spec.ControlPlane.SNIProxy
+	if sniProxy == nil {
+		return true, nil
+	}
+
+	dnsNames := []string{sniProxy.Hostname}
+	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
+		dnsNames = append(dnsNames, sniProxy.KonnectivityHostname())
+	}
+
+	return crypto.CheckCertificateSANs(r.resource.Data[kubeadmconstants.APIServerCertName], dnsNames, nil)
+}
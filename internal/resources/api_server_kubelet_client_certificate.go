@@ -100,6 +100,7 @@ func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantCo
 				constants.ControllerLabelResource: "x509",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
 			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
@@ -95,6 +95,7 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 				constants.ControllerLabelResource: "x509",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if err = ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
 			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
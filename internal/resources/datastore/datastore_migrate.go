@@ -17,6 +17,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -28,6 +29,7 @@ type Migrate struct {
 	KamajiServiceName    string
 	ShouldCleanUp        bool
 	MigrateImage         string
+	Images               images.Policy
 
 	actualDatastore  *kamajiv1alpha1.DataStore
 	desiredDatastore *kamajiv1alpha1.DataStore
@@ -36,6 +38,12 @@ type Migrate struct {
 	inProgress bool
 }
 
+// MigrateJobName returns the name of the Job migrating the data of the given Tenant Control Plane,
+// created in the Kamaji namespace.
+func MigrateJobName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("migrate-%s-%s", tenantControlPlane.GetNamespace(), tenantControlPlane.GetName())
+}
+
 func (d *Migrate) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	if len(tenantControlPlane.Status.Storage.DataStoreName) == 0 {
 		return nil
@@ -43,7 +51,7 @@ func (d *Migrate) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1
 
 	d.job = &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("migrate-%s-%s", tenantControlPlane.GetNamespace(), tenantControlPlane.GetName()),
+			Name:      MigrateJobName(tenantControlPlane),
 			Namespace: d.KamajiNamespace,
 		},
 	}
@@ -103,15 +111,19 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 			"tcp.kamaji.clastix.io/namespace": tenantControlPlane.GetNamespace(),
 			"kamaji.clastix.io/component":     "migrate",
 		})
+		utilities.SetAdditionalMetadata(d.job, tenantControlPlane.Spec.AdditionalMetadata)
+		// The Job Pod template is immutable, the additional metadata can be applied only upon the creation.
+		if len(d.job.GetUID()) == 0 {
+			utilities.SetAdditionalMetadata(&d.job.Spec.Template, tenantControlPlane.Spec.AdditionalMetadata)
+		}
 
-		d.job.Spec.Template.ObjectMeta.Labels = utilities.MergeMaps(d.job.Spec.Template.ObjectMeta.Labels, d.job.Spec.Template.ObjectMeta.Labels)
 		d.job.Spec.Template.Spec.ServiceAccountName = d.KamajiServiceAccount
 		d.job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 		if len(d.job.Spec.Template.Spec.Containers) == 0 {
 			d.job.Spec.Template.Spec.Containers = append(d.job.Spec.Template.Spec.Containers, corev1.Container{})
 		}
 		d.job.Spec.Template.Spec.Containers[0].Name = "migrate"
-		d.job.Spec.Template.Spec.Containers[0].Image = d.MigrateImage
+		d.job.Spec.Template.Spec.Containers[0].Image = d.Images.Image(tenantControlPlane, d.MigrateImage)
 		d.job.Spec.Template.Spec.Containers[0].Command = []string{"/kamaji"}
 		d.job.Spec.Template.Spec.Containers[0].Args = []string{
 			"migrate",
@@ -25,6 +25,7 @@ type SetupResource struct {
 	schema   string
 	user     string
 	password string
+	policy   kamajiv1alpha1.DataStoreUserPolicy
 }
 
 type Setup struct {
@@ -67,6 +68,7 @@ func (r *Setup) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		schema:   string(secret.Data["DB_SCHEMA"]),
 		user:     string(secret.Data["DB_USER"]),
 		password: string(secret.Data["DB_PASSWORD"]),
+		policy:   r.DataStore.UserPolicy(tenantControlPlane),
 	}
 
 	return nil
@@ -216,7 +218,8 @@ func (r *Setup) deleteDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPla
 }
 
 func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	exists, err := r.Connection.UserExists(ctx, r.resource.user)
+	// Checking the user policy too, allowing to reconcile the changes on the existing users.
+	exists, err := r.Connection.UserExists(ctx, r.resource.user, &r.resource.policy)
 	if err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if user exists")
 	}
@@ -225,7 +228,7 @@ func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 		return controllerutil.OperationResultNone, nil
 	}
 
-	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
+	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password, r.resource.policy); err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the user")
 	}
 
@@ -233,7 +236,7 @@ func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 }
 
 func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
-	exists, err := r.Connection.UserExists(ctx, r.resource.user)
+	exists, err := r.Connection.UserExists(ctx, r.resource.user, nil)
 	if err != nil {
 		return errors.Wrap(err, "unable to check if user exists")
 	}
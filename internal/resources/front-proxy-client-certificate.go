@@ -100,6 +100,7 @@ func (r *FrontProxyClientCertificate) mutate(ctx context.Context, tenantControlP
 				constants.ControllerLabelResource: "x509",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
 			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
@@ -0,0 +1,125 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// GitOpsRegistration removes the Tenant Cluster registration Secrets from the namespaces of the GitOps tools:
+// these are not garbage collected along with the Tenant Control Plane, since cross-namespace owner references are not allowed.
+type GitOpsRegistration struct {
+	resources    []*corev1.Secret
+	Client       client.Client
+	Registration gitops.Registration
+}
+
+func (r *GitOpsRegistration) GetName() string {
+	return "gitops-registration"
+}
+
+func (r *GitOpsRegistration) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resources = nil
+
+	for _, namespace := range r.Registration.Namespaces() {
+		r.resources = append(r.resources, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      gitops.SecretName(tenantControlPlane),
+				Namespace: namespace,
+			},
+		})
+	}
+
+	return nil
+}
+
+func (r *GitOpsRegistration) Delete(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	for _, secret := range r.resources {
+		if err := r.Client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the registration Secret", "namespace", secret.GetNamespace())
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GitOpsStaleRegistration removes the Tenant Cluster registration Secrets from the namespaces no more configured,
+// such as upon the change of the namespace of a GitOps tool, or when the registration is disabled.
+type GitOpsStaleRegistration struct {
+	resources    []corev1.Secret
+	Client       client.Client
+	Registration gitops.Registration
+}
+
+func (r *GitOpsStaleRegistration) GetName() string {
+	return "gitops-stale-registration"
+}
+
+func (r *GitOpsStaleRegistration) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resources = nil
+
+	secretList := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secretList, client.MatchingLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), gitops.Component))); err != nil {
+		return errors.Wrap(err, "cannot list the GitOps registration Secrets")
+	}
+
+	namespaces := sets.NewString(r.Registration.Namespaces()...)
+	// The identity Secret of the Tenant Control Plane namespace shares the same labels, although with a different name.
+	for _, secret := range secretList.Items {
+		if secret.GetName() == gitops.SecretName(tenantControlPlane) && !namespaces.Has(secret.GetNamespace()) {
+			r.resources = append(r.resources, secret)
+		}
+	}
+
+	return nil
+}
+
+func (r *GitOpsStaleRegistration) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return len(r.resources) > 0
+}
+
+func (r *GitOpsStaleRegistration) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	for i := range r.resources {
+		if err := r.Client.Delete(ctx, &r.resources[i]); err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the stale registration Secret", "namespace", r.resources[i].GetNamespace())
+
+			return false, err
+		}
+
+		logger.Info("stale registration Secret has been deleted", "namespace", r.resources[i].GetNamespace())
+	}
+
+	return false, nil
+}
+
+func (r *GitOpsStaleRegistration) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *GitOpsStaleRegistration) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *GitOpsStaleRegistration) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/gitops"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func TestGitOpsStaleRegistration(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    utilities.KamajiLabels(tcp.GetName(), gitops.Component),
+		}}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		secret("argocd", "default-tenant"),
+		secret("flux-system", "default-tenant"),
+		secret("default", "tenant-gitops-kubeconfig"),
+	).Build()
+
+	resource := &GitOpsStaleRegistration{Client: c, Registration: gitops.Registration{FluxNamespace: "flux-system", ClusterRole: "edit"}}
+	if _, err := Handle(ctx, resource, tcp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		namespace string
+		name      string
+		deleted   bool
+	}{
+		{namespace: "argocd", name: "default-tenant", deleted: true},
+		{namespace: "flux-system", name: "default-tenant"},
+		{namespace: "default", name: "tenant-gitops-kubeconfig"},
+	}
+
+	for _, tt := range tests {
+		err := c.Get(ctx, client.ObjectKey{Namespace: tt.namespace, Name: tt.name}, &corev1.Secret{})
+
+		switch {
+		case tt.deleted && !k8serrors.IsNotFound(err):
+			t.Fatalf("expected the %s/%s Secret to be deleted, got %v", tt.namespace, tt.name, err)
+		case !tt.deleted && err != nil:
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
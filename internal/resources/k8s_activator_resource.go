@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/activator"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubernetesActivatorResource is pointing the Endpoints of the Tenant Control Plane Service to the Kamaji activator
+// when scaled to zero due to inactivity: once woken up, the Service selector is restored,
+// and the Endpoints are managed back by the Kubernetes endpoints controller.
+// The activator is running on the Kamaji leader, with a listener bound to a random port: upon a leader election,
+// the Tenant Control Planes are reconciled by the new leader, pointing the Endpoints to its own listeners.
+type KubernetesActivatorResource struct {
+	resource  *corev1.Endpoints
+	Client    client.Client
+	Activator *activator.Activator
+}
+
+func (r *KubernetesActivatorResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tenantControlPlane.GetName(),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *KubernetesActivatorResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.ServedByActivator()
+}
+
+func (r *KubernetesActivatorResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	r.Activator.Close(k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.GetName()})
+
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	// Once the Service selector is restored, the Endpoints are managed back by the Kubernetes endpoints controller,
+	// overwriting the labels: if still pointing to the activator, they're deleted to let the controller recreate them.
+	if r.resource.GetLabels()[constants.ControlPlaneLabelResource] != r.GetName() {
+		return false, nil
+	}
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *KubernetesActivatorResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	port, err := r.Activator.Listen(k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.GetName()})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane, port))
+}
+
+func (r *KubernetesActivatorResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, port int32) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
+
+		r.resource.Subsets = []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{
+					{
+						IP: r.Activator.Address,
+					},
+				},
+				Ports: []corev1.EndpointPort{
+					{
+						Name:     "kube-apiserver",
+						Port:     port,
+						Protocol: corev1.ProtocolTCP,
+					},
+				},
+			},
+		}
+
+		return nil
+	}
+}
+
+func (r *KubernetesActivatorResource) GetName() string {
+	return "activator"
+}
+
+func (r *KubernetesActivatorResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubernetesActivatorResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -21,7 +22,10 @@ type KubernetesDeploymentResource struct {
 	Client             client.Client
 	DataStore          kamajiv1alpha1.DataStore
 	Name               string
+	size               *kamajiv1alpha1.ControlPlaneSize
 	KineContainerImage string
+	Scheduling         builder.Scheduling
+	Images             images.Policy
 }
 
 func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -29,7 +33,9 @@ func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv
 }
 
 func (r *KubernetesDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version
+	return !r.isStatusEqual(tenantControlPlane) ||
+		tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version ||
+		tenantControlPlane.Spec.ControlPlane.Deployment.Size != tenantControlPlane.Status.Kubernetes.Deployment.Size
 }
 
 func (r *KubernetesDeploymentResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -40,7 +46,7 @@ func (r *KubernetesDeploymentResource) CleanUp(context.Context, *kamajiv1alpha1.
 	return false, nil
 }
 
-func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+func (r *KubernetesDeploymentResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
 	r.resource = &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      tenantControlPlane.GetName(),
@@ -50,18 +56,33 @@ func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPl
 
 	r.Name = "deployment"
 
-	return nil
+	r.size, err = tenantControlPlane.GetControlPlaneSize(ctx, r.Client)
+
+	return err
 }
 
 func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
-		(builder.Deployment{
+		deploymentBuilder := builder.Deployment{
 			Client:             r.Client,
 			DataStore:          r.DataStore,
 			KineContainerImage: r.KineContainerImage,
-		}).Build(ctx, r.resource, *tenantControlPlane)
-
-		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+			Size:               r.size,
+			Scheduling:         r.Scheduling,
+			Images:             r.Images,
+		}
+		deploymentBuilder.Build(ctx, r.resource, *tenantControlPlane)
+
+		if err := controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			return err
+		}
+
+		return utilities.MergePatches(r.resource, func() client.Object {
+			desired := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: r.resource.GetName(), Namespace: r.resource.GetNamespace()}}
+			deploymentBuilder.Build(ctx, desired, *tenantControlPlane)
+
+			return desired
+		}, kamajiv1alpha1.PatchTargetDeployment, tenantControlPlane.Spec.Patches)
 	}
 }
 
@@ -75,8 +96,10 @@ func (r *KubernetesDeploymentResource) GetName() string {
 
 func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	switch {
+	case tenantControlPlane.ScaledToZero():
+		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionSleeping
 	case !r.isProgressingUpgrade():
-		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionReady
+		tenantControlPlane.Status.Kubernetes.Version.Status = tenantControlPlane.AvailableStatus()
 		tenantControlPlane.Status.Kubernetes.Version.Version = tenantControlPlane.Spec.Kubernetes.Version
 	case r.isUpgrading(tenantControlPlane):
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionUpgrading
@@ -91,6 +114,7 @@ func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.
 		Selector:         metav1.FormatLabelSelector(r.resource.Spec.Selector),
 		Name:             r.resource.GetName(),
 		Namespace:        r.resource.GetNamespace(),
+		Size:             tenantControlPlane.Spec.ControlPlane.Deployment.Size,
 		LastUpdate:       metav1.Now(),
 	}
 
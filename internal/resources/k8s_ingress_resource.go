@@ -98,63 +98,79 @@ func (r *KubernetesIngressResource) Define(_ context.Context, tenantControlPlane
 
 func (r *KubernetesIngressResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
-		labels := utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), tenantControlPlane.Spec.ControlPlane.Ingress.AdditionalMetadata.Labels)
-		r.resource.SetLabels(labels)
-
-		annotations := utilities.MergeMaps(r.resource.GetAnnotations(), tenantControlPlane.Spec.ControlPlane.Ingress.AdditionalMetadata.Annotations)
-		r.resource.SetAnnotations(annotations)
-
-		if tenantControlPlane.Spec.ControlPlane.Ingress.IngressClassName != "" {
-			r.resource.Spec.IngressClassName = &tenantControlPlane.Spec.ControlPlane.Ingress.IngressClassName
+		if err := r.build(tenantControlPlane, r.resource); err != nil {
+			return err
 		}
 
-		var rule networkingv1.IngressRule
-		if len(r.resource.Spec.Rules) > 0 {
-			rule = r.resource.Spec.Rules[0]
+		if err := controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			return err
 		}
 
-		var path networkingv1.HTTPIngressPath
-		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
-			path = rule.HTTP.Paths[0]
-		}
+		return utilities.MergePatches(r.resource, func() client.Object {
+			desired := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: r.resource.GetName(), Namespace: r.resource.GetNamespace()}}
+			// The same errors are returned upon the build of the existing Ingress.
+			_ = r.build(tenantControlPlane, desired)
 
-		path.Path = "/"
-		path.PathType = (*networkingv1.PathType)(pointer.String(string(networkingv1.PathTypePrefix)))
+			return desired
+		}, kamajiv1alpha1.PatchTargetIngress, tenantControlPlane.Spec.Patches)
+	}
+}
 
-		if path.Backend.Service == nil {
-			path.Backend.Service = &networkingv1.IngressServiceBackend{}
-		}
+// build sets the fields of the given Ingress managed by Kamaji, retaining the other ones.
+func (r *KubernetesIngressResource) build(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ingress *networkingv1.Ingress) error {
+	ingress.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+	utilities.SetAdditionalMetadata(ingress, tenantControlPlane.Spec.AdditionalMetadata, tenantControlPlane.Spec.ControlPlane.Ingress.AdditionalMetadata)
 
-		if tenantControlPlane.Status.Kubernetes.Service.Name == "" ||
-			tenantControlPlane.Status.Kubernetes.Service.Port == 0 {
-			return fmt.Errorf("ingress cannot be configured yet")
-		}
+	if tenantControlPlane.Spec.ControlPlane.Ingress.IngressClassName != "" {
+		ingress.Spec.IngressClassName = &tenantControlPlane.Spec.ControlPlane.Ingress.IngressClassName
+	}
 
-		path.Backend.Service.Name = tenantControlPlane.Status.Kubernetes.Service.Name
-		path.Backend.Service.Port.Number = tenantControlPlane.Status.Kubernetes.Service.Port
+	var rule networkingv1.IngressRule
+	if len(ingress.Spec.Rules) > 0 {
+		rule = ingress.Spec.Rules[0]
+	}
 
-		if rule.HTTP == nil {
-			rule.HTTP = &networkingv1.HTTPIngressRuleValue{
-				Paths: []networkingv1.HTTPIngressPath{
-					{},
-				},
-			}
-		}
+	var path networkingv1.HTTPIngressPath
+	if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+		path = rule.HTTP.Paths[0]
+	}
 
-		rule.HTTP.Paths[0] = path
+	path.Path = "/"
+	path.PathType = (*networkingv1.PathType)(pointer.String(string(networkingv1.PathTypePrefix)))
 
-		if len(tenantControlPlane.Spec.ControlPlane.Ingress.Hostname) == 0 {
-			return fmt.Errorf("missing hostname to expose the Tenant Control Plane using an Ingress resource")
-		}
+	if path.Backend.Service == nil {
+		path.Backend.Service = &networkingv1.IngressServiceBackend{}
+	}
+
+	if tenantControlPlane.Status.Kubernetes.Service.Name == "" ||
+		tenantControlPlane.Status.Kubernetes.Service.Port == 0 {
+		return fmt.Errorf("ingress cannot be configured yet")
+	}
 
-		rule.Host, _ = utilities.GetControlPlaneAddressAndPortFromHostname(tenantControlPlane.Spec.ControlPlane.Ingress.Hostname, 0)
+	path.Backend.Service.Name = tenantControlPlane.Status.Kubernetes.Service.Name
+	path.Backend.Service.Port.Number = tenantControlPlane.Status.Kubernetes.Service.Port
 
-		r.resource.Spec.Rules = []networkingv1.IngressRule{
-			rule,
+	if rule.HTTP == nil {
+		rule.HTTP = &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{
+				{},
+			},
 		}
+	}
+
+	rule.HTTP.Paths[0] = path
 
-		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	if len(tenantControlPlane.Spec.ControlPlane.Ingress.Hostname) == 0 {
+		return fmt.Errorf("missing hostname to expose the Tenant Control Plane using an Ingress resource")
 	}
+
+	rule.Host, _ = utilities.GetControlPlaneAddressAndPortFromHostname(tenantControlPlane.Spec.ControlPlane.Ingress.Hostname, 0)
+
+	ingress.Spec.Rules = []networkingv1.IngressRule{
+		rule,
+	}
+
+	return nil
 }
 
 func (r *KubernetesIngressResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
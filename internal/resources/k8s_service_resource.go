@@ -25,10 +25,20 @@ type KubernetesServiceResource struct {
 	Client   client.Client
 }
 
-func (r *KubernetesServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
+func (r *KubernetesServiceResource) ShouldStatusBeUpdated(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
 		tenantControlPlane.Status.Kubernetes.Service.Namespace != r.resource.GetNamespace() ||
-		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port
+		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port {
+		return true
+	}
+
+	address, err := tenantControlPlane.DeclaredControlPlaneAddress(ctx, r.Client)
+	if err != nil {
+		return false
+	}
+
+	return tenantControlPlane.Status.Kubernetes.Service.Address != address ||
+		tenantControlPlane.Status.ControlPlaneEndpoint != r.controlPlaneEndpoint(tenantControlPlane, address)
 }
 
 func (r *KubernetesServiceResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -54,11 +64,29 @@ func (r *KubernetesServiceResource) UpdateTenantControlPlaneStatus(ctx context.C
 		return err
 	}
 
-	tenantControlPlane.Status.ControlPlaneEndpoint = fmt.Sprintf("%s:%d", address, tenantControlPlane.Spec.NetworkProfile.Port)
+	tenantControlPlane.Status.Kubernetes.Service.Address = address
+	tenantControlPlane.Status.ControlPlaneEndpoint = r.controlPlaneEndpoint(tenantControlPlane, address)
 
 	return nil
 }
 
+// controlPlaneEndpoint returns the announced endpoint of the Tenant Control Plane:
+// when exposed through the SNI proxy, the hostname must be used to let the proxy route the connections,
+// as well as when exposed using an Ingress.
+func (r *KubernetesServiceResource) controlPlaneEndpoint(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, address string) string {
+	if sniProxy := tenantControlPlane.Spec.ControlPlane.SNIProxy; sniProxy != nil {
+		return fmt.Sprintf("%s:%d", sniProxy.Hostname, sniProxy.Port)
+	}
+
+	if ingress := tenantControlPlane.Spec.ControlPlane.Ingress; ingress != nil && len(ingress.Hostname) > 0 {
+		hostname, port := utilities.GetControlPlaneAddressAndPortFromHostname(ingress.Hostname, tenantControlPlane.ExposedPort())
+
+		return fmt.Sprintf("%s:%d", hostname, port)
+	}
+
+	return fmt.Sprintf("%s:%d", address, tenantControlPlane.ExposedPort())
+}
+
 func (r *KubernetesServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	r.resource = &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -80,48 +108,64 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 	address, _ := tenantControlPlane.DeclaredControlPlaneAddress(ctx, r.Client)
 
 	return func() error {
-		labels := utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Labels)
-		r.resource.SetLabels(labels)
-
-		annotations := utilities.MergeMaps(r.resource.GetAnnotations(), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Annotations)
-		r.resource.SetAnnotations(annotations)
+		r.build(tenantControlPlane, address, r.resource)
 
-		r.resource.Spec.Selector = map[string]string{
-			"kamaji.clastix.io/name": tenantControlPlane.GetName(),
+		if err := controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			return err
 		}
 
-		if len(r.resource.Spec.Ports) == 0 {
-			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		return utilities.MergePatches(r.resource, func() client.Object {
+			desired := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: r.resource.GetName(), Namespace: r.resource.GetNamespace()}}
+			r.build(tenantControlPlane, address, desired)
+
+			return desired
+		}, kamajiv1alpha1.PatchTargetService, tenantControlPlane.Spec.Patches)
+	}
+}
+
+// build sets the fields of the given Service managed by Kamaji, retaining the other ones.
+func (r *KubernetesServiceResource) build(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, address string, service *corev1.Service) {
+	service.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+	utilities.SetAdditionalMetadata(service, tenantControlPlane.Spec.AdditionalMetadata, tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata)
+
+	service.Spec.Selector = map[string]string{
+		"kamaji.clastix.io/name": tenantControlPlane.GetName(),
+	}
+	// The Tenant Control Plane is scaled to zero: the Endpoints are pointing to the Kamaji activator,
+	// and they must not be managed by the Kubernetes endpoints controller.
+	if tenantControlPlane.ServedByActivator() {
+		service.Spec.Selector = nil
+	}
+
+	if len(service.Spec.Ports) == 0 {
+		service.Spec.Ports = make([]corev1.ServicePort, 1)
+	}
+
+	service.Spec.Ports[0].Name = "kube-apiserver"
+	service.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+	service.Spec.Ports[0].Port = tenantControlPlane.Spec.NetworkProfile.GetServicePort()
+	service.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.Spec.NetworkProfile.Port))
+
+	switch tenantControlPlane.Spec.ControlPlane.Service.ServiceType {
+	case kamajiv1alpha1.ServiceTypeLoadBalancer:
+		service.Spec.Type = corev1.ServiceTypeLoadBalancer
+
+		if len(address) > 0 {
+			service.Spec.LoadBalancerIP = address
 		}
+	case kamajiv1alpha1.ServiceTypeNodePort:
+		service.Spec.Type = corev1.ServiceTypeNodePort
+		service.Spec.Ports[0].NodePort = tenantControlPlane.Spec.NetworkProfile.GetNodePort()
 
-		r.resource.Spec.Ports[0].Name = "kube-apiserver"
-		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
-		r.resource.Spec.Ports[0].Port = tenantControlPlane.Spec.NetworkProfile.Port
-		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.Spec.NetworkProfile.Port))
-
-		switch tenantControlPlane.Spec.ControlPlane.Service.ServiceType {
-		case kamajiv1alpha1.ServiceTypeLoadBalancer:
-			r.resource.Spec.Type = corev1.ServiceTypeLoadBalancer
-
-			if len(address) > 0 {
-				r.resource.Spec.LoadBalancerIP = address
-			}
-		case kamajiv1alpha1.ServiceTypeNodePort:
-			r.resource.Spec.Type = corev1.ServiceTypeNodePort
-			r.resource.Spec.Ports[0].NodePort = tenantControlPlane.Spec.NetworkProfile.Port
-
-			if tenantControlPlane.Spec.NetworkProfile.AllowAddressAsExternalIP && len(address) > 0 {
-				r.resource.Spec.ExternalIPs = []string{address}
-			}
-		default:
-			r.resource.Spec.Type = corev1.ServiceTypeClusterIP
-
-			if tenantControlPlane.Spec.NetworkProfile.AllowAddressAsExternalIP && len(address) > 0 {
-				r.resource.Spec.ExternalIPs = []string{address}
-			}
+		if tenantControlPlane.Spec.NetworkProfile.AllowAddressAsExternalIP && len(address) > 0 {
+			service.Spec.ExternalIPs = []string{address}
 		}
+	default:
+		service.Spec.Type = corev1.ServiceTypeClusterIP
 
-		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+		if tenantControlPlane.Spec.NetworkProfile.AllowAddressAsExternalIP && len(address) > 0 {
+			service.Spec.ExternalIPs = []string{address}
+		}
 	}
 }
 
@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestKubernetesServiceResourcePatches(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant", UID: "uid"}}
+	tcp.Spec.ControlPlane.Service.ServiceType = kamajiv1alpha1.ServiceTypeClusterIP
+	tcp.Spec.NetworkProfile.Address = "10.0.0.1"
+	tcp.Spec.NetworkProfile.Port = 6443
+	tcp.Spec.Patches = []kamajiv1alpha1.Patch{
+		{
+			Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService},
+			Type:   kamajiv1alpha1.PatchTypeJSON,
+			Patch:  `[{"op": "add", "path": "/spec/ports/-", "value": {"name": "metrics", "port": 9100, "protocol": "TCP"}}]`,
+		},
+		{
+			Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService},
+			Type:   kamajiv1alpha1.PatchTypeStrategicMerge,
+			Patch:  "metadata:\n  annotations:\n    example.com/patched: \"true\"\n",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp).Build()
+
+	reconcile := func() (controllerutil.OperationResult, *corev1.Service) {
+		resource := &KubernetesServiceResource{Client: c}
+		if err := resource.Define(ctx, tcp); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := resource.CreateOrUpdate(ctx, tcp)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		service := &corev1.Service{}
+		if err = c.Get(ctx, client.ObjectKeyFromObject(tcp), service); err != nil {
+			t.Fatal(err)
+		}
+
+		return result, service
+	}
+
+	if result, service := reconcile(); result != controllerutil.OperationResultCreated || len(service.Spec.Ports) != 2 || service.GetAnnotations()["example.com/patched"] != "true" {
+		t.Fatalf("expected the patched Service to be created, got %s with %+v", result, service)
+	}
+	// The patches must be applied once: the list items would be appended again otherwise.
+	if result, service := reconcile(); result != controllerutil.OperationResultNone || len(service.Spec.Ports) != 2 {
+		t.Fatalf("expected the Service to be unchanged, got %s with ports %+v", result, service.Spec.Ports)
+	}
+}
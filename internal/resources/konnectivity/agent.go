@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/images"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -25,6 +26,7 @@ type Agent struct {
 	resource     *appsv1.DaemonSet
 	Client       client.Client
 	tenantClient client.Client
+	Images       images.Policy
 }
 
 func (r *Agent) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -110,6 +112,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		}
 
 		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if r.resource.Spec.Selector == nil {
 			r.resource.Spec.Selector = &metav1.LabelSelector{}
@@ -124,6 +127,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 				"k8s-app": AgentName,
 			},
 		))
+		utilities.SetAdditionalMetadata(&r.resource.Spec.Template, tenantControlPlane.Spec.AdditionalMetadata)
 
 		r.resource.Spec.Template.Spec.PriorityClassName = "system-cluster-critical"
 		r.resource.Spec.Template.Spec.Tolerations = []corev1.Toleration{
@@ -160,7 +164,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			r.resource.Spec.Template.Spec.Containers = make([]corev1.Container, 1)
 		}
 
-		r.resource.Spec.Template.Spec.Containers[0].Image = fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Version)
+		r.resource.Spec.Template.Spec.Containers[0].Image = r.Images.Image(tenantControlPlane, fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Version))
 		r.resource.Spec.Template.Spec.Containers[0].Name = AgentName
 		r.resource.Spec.Template.Spec.Containers[0].Command = []string{"/proxy-agent"}
 
@@ -171,6 +175,12 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		args["--ca-cert"] = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 		args["--proxy-server-host"] = address
 		args["--proxy-server-port"] = fmt.Sprintf("%d", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+		// When exposed through the SNI proxy, the agents must connect using the Konnectivity hostname,
+		// sharing the same port of the API Server.
+		if sniProxy := tenantControlPlane.Spec.ControlPlane.SNIProxy; sniProxy != nil {
+			args["--proxy-server-host"] = sniProxy.KonnectivityHostname()
+			args["--proxy-server-port"] = fmt.Sprintf("%d", sniProxy.Port)
+		}
 		args["--admin-server-port"] = "8133"
 		args["--health-server-port"] = "8134"
 		args["--service-account-token-path"] = "/var/run/secrets/tokens/konnectivity-agent-token"
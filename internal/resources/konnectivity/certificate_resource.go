@@ -96,6 +96,7 @@ func (r *CertificateResource) mutate(ctx context.Context, tenantControlPlane *ka
 				constants.ControllerLabelResource: "x509",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
 			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
@@ -102,6 +102,7 @@ func (r *ClusterRoleBindingResource) mutate(tenantControlPlane *kamajiv1alpha1.T
 				"addonmanager.kubernetes.io/mode": "Reconcile",
 			},
 		))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		r.resource.RoleRef = rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
@@ -17,7 +17,6 @@ const (
 	defaultClusterName              = "kubernetes"
 	defaultUDSName                  = "/run/konnectivity/konnectivity-server.socket"
 	egressSelectorConfigurationKind = "EgressSelectorConfiguration"
-	egressSelectorConfigurationName = "cluster"
 	konnectivityCertAndKeyBaseName  = "konnectivity"
 	konnectivityKubeconfigFileName  = "konnectivity-server.conf"
 	kubeconfigAPIVersion            = "v1"
@@ -83,25 +83,14 @@ func (r *EgressSelectorConfigurationResource) UpdateTenantControlPlaneStatus(ctx
 func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
 	return func() error {
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		configuration := &apiserverv1alpha1.EgressSelectorConfiguration{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       egressSelectorConfigurationKind,
 				APIVersion: apiServerAPIVersion,
 			},
-			EgressSelections: []apiserverv1alpha1.EgressSelection{
-				{
-					Name: egressSelectorConfigurationName,
-					Connection: apiserverv1alpha1.Connection{
-						ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
-						Transport: &apiserverv1alpha1.Transport{
-							UDS: &apiserverv1alpha1.UDSTransport{
-								UDSName: defaultUDSName,
-							},
-						},
-					},
-				},
-			},
+			EgressSelections: EgressSelections(tenantControlPlane.Spec.Addons.Konnectivity),
 		}
 
 		yamlConfiguration, err := utilities.EncodeToYaml(configuration)
@@ -118,3 +107,51 @@ func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantCo
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// EgressSelections returns the routing of the API Server traffic for all the supported destinations:
+// the cluster one is routed through Konnectivity by default, the remaining ones are reached directly.
+func EgressSelections(spec *kamajiv1alpha1.KonnectivitySpec) []apiserverv1alpha1.EgressSelection {
+	types := map[kamajiv1alpha1.EgressSelectionName]kamajiv1alpha1.EgressSelectionType{
+		kamajiv1alpha1.EgressSelectionCluster:      kamajiv1alpha1.EgressSelectionTypeKonnectivity,
+		kamajiv1alpha1.EgressSelectionControlPlane: kamajiv1alpha1.EgressSelectionTypeDirect,
+		kamajiv1alpha1.EgressSelectionEtcd:         kamajiv1alpha1.EgressSelectionTypeDirect,
+	}
+
+	if spec != nil {
+		for _, selection := range spec.EgressSelections {
+			types[selection.Name] = selection.Type
+		}
+	}
+
+	names := []kamajiv1alpha1.EgressSelectionName{
+		kamajiv1alpha1.EgressSelectionCluster,
+		kamajiv1alpha1.EgressSelectionControlPlane,
+		kamajiv1alpha1.EgressSelectionEtcd,
+	}
+
+	selections := make([]apiserverv1alpha1.EgressSelection, 0, len(names))
+
+	for _, name := range names {
+		selection := apiserverv1alpha1.EgressSelection{
+			Name: string(name),
+			Connection: apiserverv1alpha1.Connection{
+				ProxyProtocol: apiserverv1alpha1.ProtocolDirect,
+			},
+		}
+
+		if types[name] == kamajiv1alpha1.EgressSelectionTypeKonnectivity {
+			selection.Connection = apiserverv1alpha1.Connection{
+				ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
+				Transport: &apiserverv1alpha1.Transport{
+					UDS: &apiserverv1alpha1.UDSTransport{
+						UDSName: defaultUDSName,
+					},
+				},
+			}
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections
+}
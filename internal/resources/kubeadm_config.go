@@ -69,14 +69,29 @@ func (r *KubeadmConfigResource) UpdateTenantControlPlaneStatus(ctx context.Conte
 	return nil
 }
 
-func (r *KubeadmConfigResource) getControlPlaneEndpoint(ingress *kamajiv1alpha1.IngressSpec, address string, port int32) string {
-	if ingress != nil && len(ingress.Hostname) > 0 {
-		address, port = utilities.GetControlPlaneAddressAndPortFromHostname(ingress.Hostname, port)
+func (r *KubeadmConfigResource) getControlPlaneEndpoint(controlPlane kamajiv1alpha1.ControlPlane, address string, port int32) string {
+	switch {
+	case controlPlane.SNIProxy != nil:
+		address, port = controlPlane.SNIProxy.Hostname, controlPlane.SNIProxy.Port
+	case controlPlane.Ingress != nil && len(controlPlane.Ingress.Hostname) > 0:
+		address, port = utilities.GetControlPlaneAddressAndPortFromHostname(controlPlane.Ingress.Hostname, port)
 	}
 
 	return fmt.Sprintf("%s:%d", address, port)
 }
 
+// getCertSANs returns the additional SANs for the API Server certificate: when exposed through the SNI proxy,
+// the Konnectivity server is using the same certificate, and its hostname must be included.
+func (r *KubeadmConfigResource) getCertSANs(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) []string {
+	certSANs := tenantControlPlane.Spec.NetworkProfile.CertSANs
+
+	if sniProxy := tenantControlPlane.Spec.ControlPlane.SNIProxy; sniProxy != nil && tenantControlPlane.Spec.Addons.Konnectivity != nil {
+		certSANs = append(append([]string{}, certSANs...), sniProxy.KonnectivityHostname())
+	}
+
+	return certSANs
+}
+
 func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -89,14 +104,15 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 		}
 
 		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		utilities.SetAdditionalMetadata(r.resource, tenantControlPlane.Spec.AdditionalMetadata)
 
 		params := kubeadm.Parameters{
 			TenantControlPlaneAddress:     address,
-			TenantControlPlanePort:        port,
+			TenantControlPlanePort:        tenantControlPlane.Spec.NetworkProfile.Port,
 			TenantControlPlaneName:        tenantControlPlane.GetName(),
 			TenantControlPlaneNamespace:   tenantControlPlane.GetNamespace(),
-			TenantControlPlaneEndpoint:    r.getControlPlaneEndpoint(tenantControlPlane.Spec.ControlPlane.Ingress, address, port),
-			TenantControlPlaneCertSANs:    tenantControlPlane.Spec.NetworkProfile.CertSANs,
+			TenantControlPlaneEndpoint:    r.getControlPlaneEndpoint(tenantControlPlane.Spec.ControlPlane, address, port),
+			TenantControlPlaneCertSANs:    r.getCertSANs(tenantControlPlane),
 			TenantControlPlanePodCIDR:     tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 			TenantControlPlaneServiceCIDR: tenantControlPlane.Spec.NetworkProfile.ServiceCIDR,
 			TenantControlPlaneVersion:     tenantControlPlane.Spec.Kubernetes.Version,
@@ -108,6 +124,13 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 		if err != nil {
 			return err
 		}
+
+		if err = kubeadm.MergeClusterConfiguration(config, tenantControlPlane.Spec.Kubernetes.ClusterConfiguration); err != nil {
+			logger.Error(err, "cannot merge the Tenant Control Plane ClusterConfiguration")
+
+			return err
+		}
+
 		if r.resource.Data, err = kubeadm.GetKubeadmInitConfigurationMap(*config); err != nil {
 			logger.Error(err, "cannot retrieve kubeadm init configuration")
 
@@ -99,7 +99,7 @@ func (k *KubernetesUpgrade) UpdateTenantControlPlaneStatus(_ context.Context, te
 	}
 
 	if tenantControlPlane.Spec.Kubernetes.Version == tenantControlPlane.Status.Kubernetes.Version.Version {
-		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionReady
+		tenantControlPlane.Status.Kubernetes.Version.Status = tenantControlPlane.AvailableStatus()
 	}
 
 	return nil
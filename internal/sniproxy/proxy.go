@@ -0,0 +1,181 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package sniproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+// Proxy is a TLS passthrough proxy: the connections are never terminated,
+// and they're forwarded to the backend matching the Server Name Indication of the TLS ClientHello message.
+// It implements the controller-runtime manager.Runnable interface.
+type Proxy struct {
+	Router           *Router
+	ListenAddress    string
+	HandshakeTimeout time.Duration
+	DialTimeout      time.Duration
+	Log              logr.Logger
+}
+
+func (p *Proxy) NeedLeaderElection() bool {
+	return false
+}
+
+func (p *Proxy) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", p.ListenAddress)
+	if err != nil {
+		return errors.Wrap(err, "cannot start the SNI proxy listener")
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	p.Log.Info("SNI proxy listening", "address", p.ListenAddress)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+
+			return errors.Wrap(err, "cannot accept connection")
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.handle(ctx, conn)
+		}()
+	}
+}
+
+func (p *Proxy) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	log := p.Log.WithValues("remote", conn.RemoteAddr().String())
+
+	_ = conn.SetReadDeadline(time.Now().Add(p.HandshakeTimeout))
+
+	serverName, clientHello, err := peekServerName(conn)
+	if err != nil {
+		log.V(1).Info("cannot read the TLS ClientHello message", "error", err.Error())
+
+		return
+	}
+
+	_ = conn.SetReadDeadline(time.Time{})
+
+	backend, ok := p.Router.Lookup(serverName)
+	if !ok {
+		log.V(1).Info("no route for the requested server name", "serverName", serverName)
+
+		return
+	}
+
+	dialer := net.Dialer{Timeout: p.DialTimeout}
+
+	upstream, err := dialer.DialContext(ctx, "tcp", backend)
+	if err != nil {
+		log.Info("cannot connect to the backend", "serverName", serverName, "backend", backend, "error", err.Error())
+
+		return
+	}
+	defer upstream.Close()
+	// The ClientHello message has been consumed to extract the Server Name Indication, replaying it to the backend.
+	if _, err = upstream.Write(clientHello); err != nil {
+		log.Info("cannot forward the TLS ClientHello message", "serverName", serverName, "backend", backend, "error", err.Error())
+
+		return
+	}
+
+	errCh := make(chan error, 2)
+
+	go pipe(upstream, conn, errCh)
+	go pipe(conn, upstream, errCh)
+	// Waiting for both directions to be completed, or the context cancellation.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-errCh:
+		}
+	}
+}
+
+func pipe(dst, src net.Conn, errCh chan<- error) {
+	_, err := io.Copy(dst, src)
+	// Propagating the half-close to the other peer, if supported.
+	if tcpConn, ok := dst.(*net.TCPConn); ok {
+		_ = tcpConn.CloseWrite()
+	}
+
+	errCh <- err
+}
+
+// peekServerName reads the TLS ClientHello message returning the requested server name,
+// along with the consumed bytes which must be replayed to the backend.
+func peekServerName(conn net.Conn) (string, []byte, error) {
+	var hello *tls.ClientHelloInfo
+
+	buffer := &bytes.Buffer{}
+	// The handshake is always failing since no certificate is provided:
+	// the aim is just to let the TLS library parse the ClientHello message.
+	_ = tls.Server(readOnlyConn{reader: io.TeeReader(conn, buffer)}, &tls.Config{
+		GetConfigForClient: func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+			hello = new(tls.ClientHelloInfo)
+			*hello = *info
+
+			return nil, nil
+		},
+	}).Handshake()
+
+	if hello == nil {
+		return "", nil, fmt.Errorf("the connection is not a valid TLS one")
+	}
+
+	if len(hello.ServerName) == 0 {
+		return "", nil, fmt.Errorf("the TLS ClientHello message is missing the Server Name Indication")
+	}
+
+	return hello.ServerName, buffer.Bytes(), nil
+}
+
+// readOnlyConn is a net.Conn which is only reading from the underlying reader,
+// preventing the TLS library from writing to the client.
+type readOnlyConn struct {
+	reader io.Reader
+}
+
+func (c readOnlyConn) Read(p []byte) (int, error)       { return c.reader.Read(p) }
+func (c readOnlyConn) Write([]byte) (int, error)        { return 0, io.ErrClosedPipe }
+func (c readOnlyConn) Close() error                     { return nil }
+func (c readOnlyConn) LocalAddr() net.Addr              { return nil }
+func (c readOnlyConn) RemoteAddr() net.Addr             { return nil }
+func (c readOnlyConn) SetDeadline(time.Time) error      { return nil }
+func (c readOnlyConn) SetReadDeadline(time.Time) error  { return nil }
+func (c readOnlyConn) SetWriteDeadline(time.Time) error { return nil }
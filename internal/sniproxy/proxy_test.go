@@ -0,0 +1,167 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package sniproxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestPeekServerName(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     func(conn net.Conn)
+		serverName string
+		wantErr    bool
+	}{
+		{
+			name: "server name",
+			client: func(conn net.Conn) {
+				_ = tls.Client(conn, &tls.Config{ServerName: "tenant-00.example.com", InsecureSkipVerify: true}).Handshake() //nolint:gosec
+			},
+			serverName: "tenant-00.example.com",
+		},
+		{
+			name: "missing server name",
+			client: func(conn net.Conn) {
+				_ = tls.Client(conn, &tls.Config{InsecureSkipVerify: true}).Handshake() //nolint:gosec
+			},
+			wantErr: true,
+		},
+		{
+			name: "IP address is not sent as server name",
+			client: func(conn net.Conn) {
+				_ = tls.Client(conn, &tls.Config{ServerName: "10.0.0.1", InsecureSkipVerify: true}).Handshake() //nolint:gosec
+			},
+			wantErr: true,
+		},
+		{
+			name: "not TLS",
+			client: func(conn net.Conn) {
+				_, _ = fmt.Fprint(conn, "GET / HTTP/1.1\r\nHost: tenant-00.example.com\r\n\r\n")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+
+			go tt.client(client)
+
+			_ = server.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+			serverName, clientHello, err := peekServerName(server)
+			_ = server.Close()
+
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Fatalf("expected error, got server name %q", serverName)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case serverName != tt.serverName:
+				t.Fatalf("expected server name %s, got %s", tt.serverName, serverName)
+			// The consumed bytes must be a TLS handshake record, which can be replayed.
+			case len(clientHello) < 5 || clientHello[0] != 0x16:
+				t.Fatalf("expected a TLS handshake record, got %v", clientHello)
+			}
+		})
+	}
+}
+
+func TestProxyHandle(t *testing.T) {
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	router := NewRouter()
+	router.Set(k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"}, map[string]string{"tenant-00.example.com": backend.Listener.Addr().String()})
+
+	proxy := &Proxy{
+		Router:           router,
+		HandshakeTimeout: 5 * time.Second,
+		DialTimeout:      5 * time.Second,
+		Log:              logr.Discard(),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go proxy.handle(ctx, conn)
+		}
+	}()
+
+	request := func(serverName string) (*http.Response, error) {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		// The backend certificate is not valid for the requested server name, what matters is the TLS handshake
+		// being completed with the backend, which is possible only if the ClientHello message has been replayed.
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: serverName, InsecureSkipVerify: true}) //nolint:gosec
+		if err = tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+
+		if _, err = fmt.Fprintf(tlsConn, "GET / HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", serverName); err != nil {
+			return nil, err
+		}
+
+		response, err := http.ReadResponse(bufio.NewReader(tlsConn), nil)
+		if err != nil {
+			return nil, err
+		}
+		_ = response.Body.Close()
+
+		return response, nil
+	}
+
+	t.Run("routed with ClientHello replay", func(t *testing.T) {
+		response, err := request("Tenant-00.Example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, response.StatusCode)
+		}
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		if _, err := request("tenant-01.example.com"); err == nil {
+			t.Fatal("expected the connection to be closed")
+		}
+	})
+}
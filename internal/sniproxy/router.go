@@ -0,0 +1,71 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package sniproxy
+
+import (
+	"strings"
+	"sync"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Router stores the backends the SNI proxy is forwarding the connections to,
+// indexed by the requested hostname, and grouped by the owning Tenant Control Plane.
+type Router struct {
+	mu     sync.RWMutex
+	routes map[string]string
+	owners map[k8stypes.NamespacedName][]string
+}
+
+func NewRouter() *Router {
+	return &Router{
+		routes: map[string]string{},
+		owners: map[k8stypes.NamespacedName][]string{},
+	}
+}
+
+// Set replaces the routes of the given owner with the provided hostname to backend address mapping.
+func (r *Router) Set(owner k8stypes.NamespacedName, routes map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delete(owner)
+
+	hostnames := make([]string, 0, len(routes))
+
+	for hostname, backend := range routes {
+		hostname = strings.ToLower(hostname)
+
+		r.routes[hostname] = backend
+		hostnames = append(hostnames, hostname)
+	}
+
+	r.owners[owner] = hostnames
+}
+
+// Delete removes all the routes of the given owner.
+func (r *Router) Delete(owner k8stypes.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delete(owner)
+}
+
+// Lookup returns the backend address for the given hostname.
+func (r *Router) Lookup(hostname string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	backend, ok := r.routes[strings.ToLower(hostname)]
+
+	return backend, ok
+}
+
+func (r *Router) delete(owner k8stypes.NamespacedName) {
+	for _, hostname := range r.owners[owner] {
+		delete(r.routes, hostname)
+	}
+
+	delete(r.owners, owner)
+}
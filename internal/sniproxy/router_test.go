@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package sniproxy
+
+import (
+	"testing"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestRouter(t *testing.T) {
+	tenant00 := k8stypes.NamespacedName{Namespace: "default", Name: "tenant-00"}
+	tenant01 := k8stypes.NamespacedName{Namespace: "default", Name: "tenant-01"}
+
+	router := NewRouter()
+	router.Set(tenant00, map[string]string{
+		"Tenant-00.Example.com":              "tenant-00.default.svc.cluster.local:6443",
+		"tenant-00-konnectivity.example.com": "tenant-00.default.svc.cluster.local:8132",
+	})
+	router.Set(tenant01, map[string]string{"tenant-01.example.com": "tenant-01.default.svc.cluster.local:6443"})
+
+	assertRoute := func(t *testing.T, hostname, expected string) {
+		t.Helper()
+
+		backend, ok := router.Lookup(hostname)
+
+		switch {
+		case len(expected) == 0 && ok:
+			t.Fatalf("expected no route for %s, got %s", hostname, backend)
+		case len(expected) > 0 && !ok:
+			t.Fatalf("expected route for %s, got none", hostname)
+		case backend != expected:
+			t.Fatalf("expected backend %s for %s, got %s", expected, hostname, backend)
+		}
+	}
+
+	t.Run("case insensitive lookup", func(t *testing.T) {
+		assertRoute(t, "tenant-00.example.com", "tenant-00.default.svc.cluster.local:6443")
+		assertRoute(t, "TENANT-00.EXAMPLE.COM", "tenant-00.default.svc.cluster.local:6443")
+		assertRoute(t, "Tenant-00-Konnectivity.example.com", "tenant-00.default.svc.cluster.local:8132")
+	})
+
+	t.Run("unknown hostname", func(t *testing.T) {
+		assertRoute(t, "tenant-02.example.com", "")
+	})
+
+	t.Run("replacement", func(t *testing.T) {
+		router.Set(tenant00, map[string]string{"tenant-00.example.org": "tenant-00.default.svc.cluster.local:6443"})
+
+		assertRoute(t, "tenant-00.example.com", "")
+		assertRoute(t, "tenant-00-konnectivity.example.com", "")
+		assertRoute(t, "tenant-00.example.org", "tenant-00.default.svc.cluster.local:6443")
+		assertRoute(t, "tenant-01.example.com", "tenant-01.default.svc.cluster.local:6443")
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		router.Delete(tenant00)
+
+		assertRoute(t, "tenant-00.example.org", "")
+		assertRoute(t, "tenant-01.example.com", "tenant-01.default.svc.cluster.local:6443")
+		// Deleting an unknown owner is a no-op.
+		router.Delete(k8stypes.NamespacedName{Namespace: "default", Name: "unknown"})
+
+		assertRoute(t, "tenant-01.example.com", "tenant-01.default.svc.cluster.local:6443")
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// SetAdditionalMetadata applies the given additional labels and annotations to the object, the latter ones taking precedence:
+// the keys applied upon a previous reconciliation, although no more declared, are removed from the object.
+// It must be called once the object labels and annotations managed by Kamaji have been set.
+func SetAdditionalMetadata(object metav1.Object, metadata ...kamajiv1alpha1.AdditionalMetadata) {
+	var labels, annotations []map[string]string
+
+	for _, m := range metadata {
+		labels, annotations = append(labels, m.Labels), append(annotations, m.Annotations)
+	}
+
+	annotationsMap := object.GetAnnotations()
+	if annotationsMap == nil {
+		annotationsMap = map[string]string{}
+	}
+
+	labelsMap, labelKeys := applyAdditionalMetadata(object.GetLabels(), annotationsMap[constants.AdditionalLabels], MergeMaps(labels...))
+	annotationsMap, annotationKeys := applyAdditionalMetadata(annotationsMap, annotationsMap[constants.AdditionalAnnotations], MergeMaps(annotations...))
+
+	for key, value := range map[string]string{constants.AdditionalLabels: labelKeys, constants.AdditionalAnnotations: annotationKeys} {
+		if len(value) == 0 {
+			delete(annotationsMap, key)
+
+			continue
+		}
+
+		annotationsMap[key] = value
+	}
+
+	if len(labelsMap) == 0 {
+		labelsMap = nil
+	}
+
+	if len(annotationsMap) == 0 {
+		annotationsMap = nil
+	}
+
+	object.SetLabels(labelsMap)
+	object.SetAnnotations(annotationsMap)
+}
+
+// applyAdditionalMetadata removes the previously applied keys from the current map, and sets the desired ones,
+// returning the resulting map along with the comma separated list of the applied keys.
+func applyAdditionalMetadata(current map[string]string, applied string, desired map[string]string) (map[string]string, string) {
+	result := MergeMaps(current)
+
+	for _, key := range strings.Split(applied, ",") {
+		if _, ok := desired[key]; !ok && !strings.HasPrefix(key, constants.ReservedPrefix) {
+			delete(result, key)
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+
+	for key, value := range desired {
+		if strings.HasPrefix(key, constants.ReservedPrefix) {
+			continue
+		}
+
+		result[key] = value
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return result, strings.Join(keys, ",")
+}
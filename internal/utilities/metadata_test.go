@@ -0,0 +1,68 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+func TestSetAdditionalMetadata(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      KamajiLabels("tenant", "ca"),
+			Annotations: map[string]string{constants.Checksum: "checksum"},
+		},
+	}
+
+	SetAdditionalMetadata(secret,
+		kamajiv1alpha1.AdditionalMetadata{
+			Labels:      map[string]string{"cost-center": "a", "backup": "true", constants.ControlPlaneLabelKey: "other"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		kamajiv1alpha1.AdditionalMetadata{
+			Labels: map[string]string{"cost-center": "b"},
+		},
+	)
+
+	expectedLabels := MergeMaps(KamajiLabels("tenant", "ca"), map[string]string{"cost-center": "b", "backup": "true"})
+	if !reflect.DeepEqual(secret.GetLabels(), expectedLabels) {
+		t.Fatalf("expected labels %v, got %v", expectedLabels, secret.GetLabels())
+	}
+
+	expectedAnnotations := map[string]string{
+		constants.Checksum:              "checksum",
+		"owner":                         "team-a",
+		constants.AdditionalLabels:      "backup,cost-center",
+		constants.AdditionalAnnotations: "owner",
+	}
+	if !reflect.DeepEqual(secret.GetAnnotations(), expectedAnnotations) {
+		t.Fatalf("expected annotations %v, got %v", expectedAnnotations, secret.GetAnnotations())
+	}
+	// Labels and annotations set by others must be retained, unlike the ones dropped from the additional metadata.
+	secret.Labels["external"] = "true"
+	secret.Annotations["external"] = "true"
+
+	SetAdditionalMetadata(secret, kamajiv1alpha1.AdditionalMetadata{Labels: map[string]string{"backup": "false"}})
+
+	expectedLabels = MergeMaps(KamajiLabels("tenant", "ca"), map[string]string{"backup": "false", "external": "true"})
+	if !reflect.DeepEqual(secret.GetLabels(), expectedLabels) {
+		t.Fatalf("expected labels %v, got %v", expectedLabels, secret.GetLabels())
+	}
+
+	expectedAnnotations = map[string]string{
+		constants.Checksum:         "checksum",
+		"external":                 "true",
+		constants.AdditionalLabels: "backup",
+	}
+	if !reflect.DeepEqual(secret.GetAnnotations(), expectedAnnotations) {
+		t.Fatalf("expected annotations %v, got %v", expectedAnnotations, secret.GetAnnotations())
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// ApplyPatches applies, in the declared order, the Tenant Control Plane patches targeting the given object of the given kind:
+// an error is returned if a patch cannot be applied, or it's modifying the owner references, or the selector labels.
+func ApplyPatches(object client.Object, kind kamajiv1alpha1.PatchTargetKind, patches []kamajiv1alpha1.Patch) error {
+	for i, patch := range patches {
+		if patch.Target.Kind != kind || len(patch.Target.Name) > 0 && patch.Target.Name != object.GetName() {
+			continue
+		}
+
+		if err := applyPatch(object, patch); err != nil {
+			return fmt.Errorf("cannot apply the patch %d to the %s %s: %w", i, kind, object.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// MergePatches applies the Tenant Control Plane patches targeting the given kind to the object retrieved from the API Server,
+// and already mutated by the resource builder. The builders are not resetting the fields they're not managing:
+// the patches are applied to the desired object, built from scratch, and only the resulting changes are merged
+// into the given one, preventing them from being applied more than once, such as the items appended to a list.
+// The desired object is built only when a patch is targeting the given one.
+func MergePatches(object client.Object, build func() client.Object, kind kamajiv1alpha1.PatchTargetKind, patches []kamajiv1alpha1.Patch) error {
+	targeted := false
+
+	for _, patch := range patches {
+		if patch.Target.Kind == kind && (len(patch.Target.Name) == 0 || patch.Target.Name == object.GetName()) {
+			targeted = true
+
+			break
+		}
+	}
+
+	if !targeted {
+		return nil
+	}
+
+	desired := build()
+
+	original, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+
+	if err = ApplyPatches(desired, kind, patches); err != nil {
+		return err
+	}
+
+	patched, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+
+	changes, err := strategicpatch.CreateTwoWayMergePatch(original, patched, desired)
+	if err != nil {
+		return fmt.Errorf("cannot compute the changes of the patches to the %s %s: %w", kind, object.GetName(), err)
+	}
+
+	current, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	merged, err := strategicpatch.StrategicMergePatch(current, changes, object)
+	if err != nil {
+		return fmt.Errorf("cannot merge the changes of the patches to the %s %s: %w", kind, object.GetName(), err)
+	}
+
+	result := reflect.New(reflect.TypeOf(object).Elem()).Interface().(client.Object) //nolint:forcetypeassert
+	if err = json.Unmarshal(merged, result); err != nil {
+		return err
+	}
+
+	if err = checkProtectedFields(object, result); err != nil {
+		return fmt.Errorf("cannot apply the patches to the %s %s: %w", kind, object.GetName(), err)
+	}
+
+	reflect.ValueOf(object).Elem().Set(reflect.ValueOf(result).Elem())
+
+	return nil
+}
+
+func applyPatch(object client.Object, patch kamajiv1alpha1.Patch) error {
+	original, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	body, err := yaml.ToJSON([]byte(patch.Patch))
+	if err != nil {
+		return fmt.Errorf("the patch is not valid JSON, or YAML: %w", err)
+	}
+
+	var patched []byte
+
+	switch patch.Type {
+	case kamajiv1alpha1.PatchTypeJSON:
+		var operations jsonpatch.Patch
+
+		if operations, err = jsonpatch.DecodePatch(body); err != nil {
+			return err
+		}
+
+		patched, err = operations.Apply(original)
+	case kamajiv1alpha1.PatchTypeStrategicMerge, "":
+		patched, err = strategicpatch.StrategicMergePatch(original, body, object)
+	default:
+		return fmt.Errorf("unsupported patch type %s", patch.Type)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	result := reflect.New(reflect.TypeOf(object).Elem()).Interface().(client.Object) //nolint:forcetypeassert
+	if err = json.Unmarshal(patched, result); err != nil {
+		return err
+	}
+
+	if err = checkProtectedFields(object, result); err != nil {
+		return err
+	}
+
+	reflect.ValueOf(object).Elem().Set(reflect.ValueOf(result).Elem())
+
+	return nil
+}
+
+// checkProtectedFields ensures the patched object is retaining the identity, the owner references,
+// and the selector labels, of the original one.
+func checkProtectedFields(original, patched client.Object) error {
+	if original.GetName() != patched.GetName() || original.GetNamespace() != patched.GetNamespace() {
+		return fmt.Errorf("the name, and the namespace, cannot be modified")
+	}
+
+	if !equality.Semantic.DeepEqual(original.GetOwnerReferences(), patched.GetOwnerReferences()) {
+		return fmt.Errorf("the owner references cannot be modified")
+	}
+
+	switch o := original.(type) {
+	case *appsv1.Deployment:
+		p := patched.(*appsv1.Deployment) //nolint:forcetypeassert
+
+		if !equality.Semantic.DeepEqual(o.Spec.Selector, p.Spec.Selector) {
+			return fmt.Errorf("the selector cannot be modified")
+		}
+
+		if o.Spec.Selector != nil {
+			for key, value := range o.Spec.Selector.MatchLabels {
+				if p.Spec.Template.GetLabels()[key] != value {
+					return fmt.Errorf("the Pod template label %s is used by the selector, it cannot be modified", key)
+				}
+			}
+		}
+	case *corev1.Service:
+		p := patched.(*corev1.Service) //nolint:forcetypeassert
+
+		if !equality.Semantic.DeepEqual(o.Spec.Selector, p.Spec.Selector) {
+			return fmt.Errorf("the selector cannot be modified")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func patchesTestDeployment() *appsv1.Deployment {
+	labels := map[string]string{"kamaji.clastix.io/name": "tenant"}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "tenant",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "kamaji.clastix.io/v1alpha1", Kind: "TenantControlPlane", Name: "tenant", Controller: pointer.Bool(true)}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "kube-apiserver", Args: []string{"--secure-port=6443"}},
+						{Name: "kube-scheduler"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    kamajiv1alpha1.PatchTargetKind
+		patches []kamajiv1alpha1.Patch
+		check   func(t *testing.T, deployment *appsv1.Deployment)
+		wantErr bool
+	}{
+		{
+			name: "strategic merge",
+			kind: kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{
+				Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment},
+				Type:   kamajiv1alpha1.PatchTypeStrategicMerge,
+				Patch:  "spec:\n  template:\n    spec:\n      containers:\n      - name: kube-apiserver\n        imagePullPolicy: Always\n",
+			}},
+			check: func(t *testing.T, deployment *appsv1.Deployment) {
+				t.Helper()
+
+				containers := deployment.Spec.Template.Spec.Containers
+				if len(containers) != 2 || containers[0].ImagePullPolicy != corev1.PullAlways || containers[0].Args[0] != "--secure-port=6443" {
+					t.Fatalf("unexpected containers %+v", containers)
+				}
+			},
+		},
+		{
+			name: "JSON patches in order",
+			kind: kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{
+				{
+					Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment, Name: "tenant"},
+					Type:   kamajiv1alpha1.PatchTypeJSON,
+					Patch:  `[{"op": "add", "path": "/spec/template/spec/containers/0/args/-", "value": "--v=4"}]`,
+				},
+				{
+					Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment},
+					Type:   kamajiv1alpha1.PatchTypeJSON,
+					Patch:  `[{"op": "replace", "path": "/spec/template/spec/containers/0/args/1", "value": "--v=6"}]`,
+				},
+			},
+			check: func(t *testing.T, deployment *appsv1.Deployment) {
+				t.Helper()
+
+				if args := deployment.Spec.Template.Spec.Containers[0].Args; len(args) != 2 || args[1] != "--v=6" {
+					t.Fatalf("unexpected args %v", args)
+				}
+			},
+		},
+		{
+			name: "other targets are skipped",
+			kind: kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{
+				{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: "invalid"},
+				{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment, Name: "other"}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: "invalid"},
+			},
+			check: func(t *testing.T, deployment *appsv1.Deployment) {
+				t.Helper()
+
+				if len(deployment.Spec.Template.Spec.Containers[0].Args) != 1 {
+					t.Fatalf("expected the Deployment to be untouched")
+				}
+			},
+		},
+		{
+			name:    "missing path",
+			kind:    kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: `[{"op": "replace", "path": "/spec/foo/bar", "value": 1}]`}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid body",
+			kind:    kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment}, Type: kamajiv1alpha1.PatchTypeStrategicMerge, Patch: "spec: ["}},
+			wantErr: true,
+		},
+		{
+			name:    "selector",
+			kind:    kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment}, Patch: `{"spec": {"selector": {"matchLabels": {"foo": "bar"}}}}`}},
+			wantErr: true,
+		},
+		{
+			name:    "selector labels",
+			kind:    kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment}, Patch: `{"spec": {"template": {"metadata": {"labels": {"kamaji.clastix.io/name": "other"}}}}}`}},
+			wantErr: true,
+		},
+		{
+			name:    "owner references",
+			kind:    kamajiv1alpha1.PatchTargetDeployment,
+			patches: []kamajiv1alpha1.Patch{{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetDeployment}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: `[{"op": "remove", "path": "/metadata/ownerReferences"}]`}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := patchesTestDeployment()
+
+			err := ApplyPatches(deployment, tt.kind, tt.patches)
+
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Fatal("expected error")
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			default:
+				tt.check(t, deployment)
+			}
+		})
+	}
+}
+
+func TestApplyPatchesServiceSelector(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"kamaji.clastix.io/name": "tenant"}},
+	}
+
+	patches := []kamajiv1alpha1.Patch{{
+		Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService},
+		Type:   kamajiv1alpha1.PatchTypeStrategicMerge,
+		Patch:  `{"spec": {"externalTrafficPolicy": "Local"}}`,
+	}}
+
+	if err := ApplyPatches(service, kamajiv1alpha1.PatchTargetService, patches); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if service.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal {
+		t.Fatalf("expected the patch to be applied, got %+v", service.Spec)
+	}
+
+	patches[0].Patch = `{"spec": {"selector": null}}`
+
+	if err := ApplyPatches(service, kamajiv1alpha1.PatchTargetService, patches); err == nil {
+		t.Fatal("expected the selector modification to be rejected")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type ControlPlaneSizeValidation struct {
+	Client client.Client
+}
+
+func (c ControlPlaneSizeValidation) OnCreate(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+// OnDelete rejects the removal of a ControlPlaneSize in use,
+// the Tenant Control Planes referring to it could not be reconciled anymore.
+func (c ControlPlaneSizeValidation) OnDelete(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		size := object.(*kamajiv1alpha1.ControlPlaneSize) //nolint:forcetypeassert
+
+		tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+		if err := c.Client.List(ctx, tcpList, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedSizeKey, size.GetName())}); err != nil {
+			return nil, errors.Wrap(err, "cannot retrieve TenantControlPlane list using the ControlPlaneSize")
+		}
+
+		if len(tcpList.Items) > 0 {
+			return nil, fmt.Errorf("the ControlPlaneSize is used by %d TenantControlPlanes and cannot be removed", len(tcpList.Items))
+		}
+
+		return nil, nil
+	}
+}
+
+func (c ControlPlaneSizeValidation) OnUpdate(runtime.Object, runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
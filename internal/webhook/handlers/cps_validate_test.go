@@ -0,0 +1,53 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestControlPlaneSizeValidationOnDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+	tcp.Spec.ControlPlane.Deployment.Size = "small"
+
+	indexer := &kamajiv1alpha1.TenantControlPlaneSpecSize{}
+	handler := ControlPlaneSizeValidation{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp).WithIndex(indexer.Object(), indexer.Field(), indexer.ExtractValue()).Build(),
+	}
+
+	tests := []struct {
+		name    string
+		size    string
+		wantErr bool
+	}{
+		{name: "used", size: "small", wantErr: true},
+		{name: "unused", size: "large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.OnDelete(&kamajiv1alpha1.ControlPlaneSize{ObjectMeta: metav1.ObjectMeta{Name: tt.size}})(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
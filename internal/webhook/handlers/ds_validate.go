@@ -6,6 +6,9 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"net"
+	"regexp"
+	"time"
 
 	"github.com/pkg/errors"
 	"gomodules.xyz/jsonpatch/v2"
@@ -56,12 +59,45 @@ func (d DataStoreValidation) OnUpdate(object runtime.Object, oldObj runtime.Obje
 		if oldDs.Spec.Driver != newDs.Spec.Driver {
 			return nil, fmt.Errorf("driver of a DataStore cannot be changed")
 		}
+		// The Tenant Control Planes data would be left in the previous database, or schema.
+		if oldDs.SharedDatabase() != newDs.SharedDatabase() {
+			tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+			if err := d.Client.List(ctx, tcpList, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, newDs.GetName())}); err != nil {
+				return nil, errors.Wrap(err, "cannot retrieve TenantControlPlane list used by the DataStore")
+			}
+
+			if len(tcpList.Items) > 0 {
+				return nil, fmt.Errorf("the PostgreSQL isolation of a DataStore used by TenantControlPlanes cannot be changed")
+			}
+		}
 
 		return nil, d.validate(ctx, *newDs)
 	}
 }
 
 func (d DataStoreValidation) validate(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	if opts := ds.Spec.PostgreSQL; opts != nil && opts.Isolation == kamajiv1alpha1.PostgreSQLSchemaIsolation && len(opts.SharedDatabase) == 0 {
+		return fmt.Errorf("the shared database is required when using the PostgreSQL Schema isolation")
+	}
+
+	if ds.Spec.Drain != nil {
+		if err := d.validateDrain(ctx, ds); err != nil {
+			return err
+		}
+	}
+
+	if policy := ds.Spec.UserPolicy; policy != nil {
+		if err := validateUserPolicy(ds.Spec.Driver, *policy); err != nil {
+			return err
+		}
+	}
+
+	if maintenance := ds.Spec.EtcdMaintenance; maintenance != nil {
+		if err := validateEtcdMaintenance(ds.Spec.Driver, *maintenance); err != nil {
+			return err
+		}
+	}
+
 	if ds.Spec.BasicAuth != nil {
 		if err := d.validateBasicAuth(ctx, ds); err != nil {
 			return err
@@ -75,6 +111,98 @@ func (d DataStoreValidation) validate(ctx context.Context, ds kamajiv1alpha1.Dat
 	return nil
 }
 
+func (d DataStoreValidation) validateDrain(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	if !ds.Spec.Cordoned {
+		return fmt.Errorf("the DataStore must be cordoned to be drained")
+	}
+
+	if ds.Spec.Drain.TargetDataStore == ds.GetName() {
+		return fmt.Errorf("the DataStore cannot be drained to itself")
+	}
+
+	target := &kamajiv1alpha1.DataStore{}
+	if err := d.Client.Get(ctx, types.NamespacedName{Name: ds.Spec.Drain.TargetDataStore}, target); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("the drain target DataStore %s does not exist", ds.Spec.Drain.TargetDataStore)
+		}
+
+		return errors.Wrap(err, "cannot retrieve the drain target DataStore")
+	}
+
+	if target.Spec.Cordoned {
+		return fmt.Errorf("the drain target DataStore %s is cordoned", target.GetName())
+	}
+	// The data is migrated using the origin driver.
+	if target.Spec.Driver != ds.Spec.Driver {
+		return fmt.Errorf("the drain target DataStore %s must use the %s driver", target.GetName(), ds.Spec.Driver)
+	}
+
+	return nil
+}
+
+// mysqlHostPattern matches the host names, IP addresses, and wildcards supported by the MySQL user accounts.
+var mysqlHostPattern = regexp.MustCompile(`^[a-zA-Z0-9.:%_-]+$`)
+
+// validateUserPolicy ensures the restrictions of the datastore users are supported by the given driver.
+func validateUserPolicy(driver kamajiv1alpha1.Driver, policy kamajiv1alpha1.DataStoreUserPolicy) error {
+	switch driver {
+	case kamajiv1alpha1.EtcdDriver:
+		if len(policy.AllowedHosts) > 0 || policy.MaxConnections != nil || policy.StatementTimeout != nil || policy.LockTimeout != nil {
+			return fmt.Errorf("the user policy is not supported by the etcd driver")
+		}
+	case kamajiv1alpha1.KineMySQLDriver:
+		if policy.StatementTimeout != nil || policy.LockTimeout != nil {
+			return fmt.Errorf("the statement and lock timeouts are not supported by the MySQL driver")
+		}
+	case kamajiv1alpha1.KinePostgreSQLDriver:
+		if len(policy.AllowedHosts) > 0 {
+			return fmt.Errorf("the allowed hosts are not supported by the PostgreSQL driver, the client authentication must be configured in the pg_hba.conf file")
+		}
+	}
+
+	for _, host := range policy.AllowedHosts {
+		if _, network, err := net.ParseCIDR(host); err == nil {
+			if network.IP.To4() == nil {
+				return fmt.Errorf("the allowed host %s is not valid, only IPv4 CIDRs are supported", host)
+			}
+
+			continue
+		}
+
+		if !mysqlHostPattern.MatchString(host) {
+			return fmt.Errorf("the allowed host %s is not valid, it must be a host name, an IP address, or an IPv4 CIDR", host)
+		}
+	}
+	// The PostgreSQL timeouts are expressed in milliseconds, and zero would disable them.
+	if timeout := policy.StatementTimeout; timeout != nil && timeout.Duration < time.Millisecond {
+		return fmt.Errorf("the statement timeout must be at least one millisecond")
+	}
+
+	if timeout := policy.LockTimeout; timeout != nil && timeout.Duration < time.Millisecond {
+		return fmt.Errorf("the lock timeout must be at least one millisecond")
+	}
+
+	return nil
+}
+
+// validateEtcdMaintenance ensures the maintenance is requested for an etcd DataStore,
+// and it's not defragmenting the members continuously.
+func validateEtcdMaintenance(driver kamajiv1alpha1.Driver, maintenance kamajiv1alpha1.EtcdMaintenanceSpec) error {
+	if driver != kamajiv1alpha1.EtcdDriver {
+		return fmt.Errorf("the etcd maintenance is not supported by the %s driver", driver)
+	}
+
+	if maintenance.DefragmentationInterval.Duration < time.Hour {
+		return fmt.Errorf("the defragmentation interval must be at least one hour")
+	}
+
+	if maintenance.DefragmentationThreshold.Sign() < 0 {
+		return fmt.Errorf("the defragmentation threshold cannot be negative")
+	}
+
+	return nil
+}
+
 func (d DataStoreValidation) validateBasicAuth(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
 	if err := d.validateContentReference(ctx, ds.Spec.BasicAuth.Password); err != nil {
 		return fmt.Errorf("basic-auth password is not valid, %w", err)
@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestValidateUserPolicy(t *testing.T) {
+	maxConnections := int32(10)
+
+	tests := []struct {
+		name    string
+		driver  kamajiv1alpha1.Driver
+		policy  kamajiv1alpha1.DataStoreUserPolicy
+		wantErr bool
+	}{
+		{name: "empty", driver: kamajiv1alpha1.EtcdDriver},
+		{name: "etcd limits", driver: kamajiv1alpha1.EtcdDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{MaxConnections: &maxConnections}, wantErr: true},
+		{name: "MySQL hosts", driver: kamajiv1alpha1.KineMySQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{AllowedHosts: []string{"10.0.0.0/8", "192.168.1.10", "kamaji.kamaji-system.svc", "%.example.com"}, MaxConnections: &maxConnections}},
+		{name: "MySQL IPv6 CIDR", driver: kamajiv1alpha1.KineMySQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{AllowedHosts: []string{"fd00::/8"}}, wantErr: true},
+		{name: "MySQL quoted host", driver: kamajiv1alpha1.KineMySQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{AllowedHosts: []string{"host`; DROP USER root"}}, wantErr: true},
+		{name: "MySQL timeouts", driver: kamajiv1alpha1.KineMySQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{StatementTimeout: &metav1.Duration{Duration: time.Second}}, wantErr: true},
+		{name: "PostgreSQL timeouts", driver: kamajiv1alpha1.KinePostgreSQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{MaxConnections: &maxConnections, StatementTimeout: &metav1.Duration{Duration: 30 * time.Second}, LockTimeout: &metav1.Duration{Duration: 5 * time.Second}}},
+		{name: "PostgreSQL hosts", driver: kamajiv1alpha1.KinePostgreSQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{AllowedHosts: []string{"10.0.0.0/8"}}, wantErr: true},
+		{name: "PostgreSQL sub-millisecond timeout", driver: kamajiv1alpha1.KinePostgreSQLDriver, policy: kamajiv1alpha1.DataStoreUserPolicy{LockTimeout: &metav1.Duration{Duration: time.Microsecond}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUserPolicy(tt.driver, tt.policy)
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneAdditionalMetadata struct{}
+
+func (t TenantControlPlaneAdditionalMetadata) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneAdditionalMetadata) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneAdditionalMetadata) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneAdditionalMetadata) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	metadata := tcp.Spec.AdditionalMetadata
+	path := field.NewPath("spec", "additionalMetadata")
+
+	errs := metav1validation.ValidateLabels(metadata.Labels, path.Child("labels"))
+	errs = append(errs, apivalidation.ValidateAnnotations(metadata.Annotations, path.Child("annotations"))...)
+
+	for name, values := range map[string]map[string]string{"labels": metadata.Labels, "annotations": metadata.Annotations} {
+		for key := range values {
+			if strings.HasPrefix(key, constants.ReservedPrefix) {
+				errs = append(errs, field.Forbidden(path.Child(name).Key(key), fmt.Sprintf("the %s prefix is reserved", constants.ReservedPrefix)))
+			}
+		}
+	}
+
+	return errs.ToAggregate()
+}
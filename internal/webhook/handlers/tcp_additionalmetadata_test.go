@@ -0,0 +1,39 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneAdditionalMetadataValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata kamajiv1alpha1.AdditionalMetadata
+		wantErr  bool
+	}{
+		{name: "empty"},
+		{name: "valid", metadata: kamajiv1alpha1.AdditionalMetadata{Labels: map[string]string{"example.com/cost-center": "a"}, Annotations: map[string]string{"owner": "team a"}}},
+		{name: "invalid label", metadata: kamajiv1alpha1.AdditionalMetadata{Labels: map[string]string{"cost center": "a"}}, wantErr: true},
+		{name: "reserved label", metadata: kamajiv1alpha1.AdditionalMetadata{Labels: map[string]string{"kamaji.clastix.io/name": "a"}}, wantErr: true},
+		{name: "reserved annotation", metadata: kamajiv1alpha1.AdditionalMetadata{Annotations: map[string]string{"kamaji.clastix.io/checksum": "a"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.AdditionalMetadata = tt.metadata
+
+			_, err := TenantControlPlaneAdditionalMetadata{}.OnCreate(tcp)(context.Background(), admission.Request{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
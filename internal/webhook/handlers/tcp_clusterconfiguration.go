@@ -0,0 +1,68 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneClusterConfiguration ensures the partial kubeadm ClusterConfiguration can be merged
+// into the generated one, without changing the fields managed by Kamaji.
+type TenantControlPlaneClusterConfiguration struct{}
+
+func (t TenantControlPlaneClusterConfiguration) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneClusterConfiguration) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneClusterConfiguration) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneClusterConfiguration) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if len(tcp.Spec.Kubernetes.ClusterConfiguration) == 0 {
+		return nil
+	}
+	// The actual endpoints are not known upon admission, although they're not affecting the merge:
+	// placeholder values are used to detect changes to the fields managed by Kamaji.
+	config, err := kubeadm.CreateKubeadmInitConfiguration(kubeadm.Parameters{
+		TenantControlPlaneName:        tcp.GetName(),
+		TenantControlPlaneNamespace:   tcp.GetNamespace(),
+		TenantControlPlaneEndpoint:    fmt.Sprintf("%s:%d", tcp.GetName(), tcp.ExposedPort()),
+		TenantControlPlanePort:        tcp.Spec.NetworkProfile.Port,
+		TenantControlPlanePodCIDR:     tcp.Spec.NetworkProfile.PodCIDR,
+		TenantControlPlaneServiceCIDR: tcp.Spec.NetworkProfile.ServiceCIDR,
+		TenantControlPlaneVersion:     tcp.Spec.Kubernetes.Version,
+		ETCDs:                         []string{"https://127.0.0.1:2379"},
+	})
+	if err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane ClusterConfiguration check, %w", err)
+	}
+
+	if err = kubeadm.MergeClusterConfiguration(config, tcp.Spec.Kubernetes.ClusterConfiguration); err != nil {
+		return fmt.Errorf("invalid Tenant Control Plane ClusterConfiguration, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneClusterConfiguration(t *testing.T) {
+	tests := []struct {
+		name                 string
+		clusterConfiguration string
+		wantErr              bool
+	}{
+		{name: "empty"},
+		{name: "extra args", clusterConfiguration: "apiServer:\n  extraArgs:\n    audit-log-maxage: \"30\"\ncontrollerManager:\n  extraArgs:\n    node-monitor-grace-period: 20s\n"},
+		{name: "JSON", clusterConfiguration: `{"dns": {"imageRepository": "registry.example.com/coredns"}}`},
+		{name: "unknown field", clusterConfiguration: "apiServer:\n  foo: bar\n", wantErr: true},
+		{name: "invalid", clusterConfiguration: "apiServer: [", wantErr: true},
+		{name: "control plane endpoint", clusterConfiguration: "controlPlaneEndpoint: tenant.example.com:443\n", wantErr: true},
+		{name: "etcd", clusterConfiguration: "etcd:\n  external:\n    endpoints:\n    - https://etcd.example.com:2379\n", wantErr: true},
+		{name: "certificates dir", clusterConfiguration: "certificatesDir: /tmp\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+			tcp.Spec.Kubernetes.Version = "v1.26.0"
+			tcp.Spec.NetworkProfile.Port = 6443
+			tcp.Spec.Kubernetes.ClusterConfiguration = tt.clusterConfiguration
+
+			_, err := (TenantControlPlaneClusterConfiguration{}).OnCreate(tcp)(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
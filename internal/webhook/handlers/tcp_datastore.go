@@ -26,7 +26,7 @@ func (t TenantControlPlaneDataStore) OnCreate(object runtime.Object) AdmissionRe
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
-		return nil, t.check(ctx, tcp.Spec.DataStore)
+		return nil, t.check(ctx, tcp, true)
 	}
 }
 
@@ -34,22 +34,35 @@ func (t TenantControlPlaneDataStore) OnDelete(runtime.Object) AdmissionResponse
 	return utils.NilOp()
 }
 
-func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
-		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
-		return nil, t.check(ctx, tcp.Spec.DataStore)
+		return nil, t.check(ctx, newTCP, newTCP.Spec.DataStore != oldTCP.Spec.DataStore)
 	}
 }
 
-func (t TenantControlPlaneDataStore) check(ctx context.Context, dataStoreName string) error {
-	if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, &kamajiv1alpha1.DataStore{}); err != nil {
+// check ensures the DataStore exists: when it's assigned to the Tenant Control Plane,
+// upon its creation, or migration, the DataStore must not be cordoned.
+func (t TenantControlPlaneDataStore) check(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, assigned bool) error {
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return fmt.Errorf("%s DataStore does not exist", dataStoreName)
+			return fmt.Errorf("%s DataStore does not exist", tcp.Spec.DataStore)
 		}
 
 		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore check, %w", err)
 	}
 
+	if assigned && ds.Spec.Cordoned {
+		return fmt.Errorf("the %s DataStore is cordoned and cannot be used by new, or migrating, Tenant Control Planes", ds.GetName())
+	}
+	// The user policy overrides must be supported by the DataStore driver.
+	if tcp.Spec.DataStoreUserPolicy != nil {
+		if err := validateUserPolicy(ds.Spec.Driver, ds.UserPolicy(tcp)); err != nil {
+			return fmt.Errorf("the DataStore user policy is not valid, %w", err)
+		}
+	}
+
 	return nil
 }
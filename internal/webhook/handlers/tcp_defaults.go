@@ -10,7 +10,9 @@ import (
 	"github.com/pkg/errors"
 	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -18,29 +20,39 @@ import (
 )
 
 type TenantControlPlaneDefaults struct {
+	Client           client.Client
 	DefaultDatastore string
 }
 
 func (t TenantControlPlaneDefaults) OnCreate(object runtime.Object) AdmissionResponse {
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
-
+		// The cordoned default DataStore is skipped, thus the DataStore must be specified.
 		if len(tcp.Spec.DataStore) == 0 {
-			operations, err := utils.JSONPatch(tcp, func() {
-				tcp.Spec.DataStore = t.DefaultDatastore
-			})
-			if err != nil {
-				return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane creation")
+			ds := &kamajiv1alpha1.DataStore{}
+			if err := t.Client.Get(ctx, types.NamespacedName{Name: t.DefaultDatastore}, ds); err != nil {
+				return nil, errors.Wrap(err, "cannot retrieve the default DataStore")
 			}
 
-			return operations, nil
+			if ds.Spec.Cordoned {
+				return nil, fmt.Errorf("the default DataStore %s is cordoned, a DataStore must be specified", ds.GetName())
+			}
 		}
 
-		if tcp.Spec.ControlPlane.Deployment.Replicas == nil {
-			tcp.Spec.ControlPlane.Deployment.Replicas = pointer.Int32(2)
+		operations, err := utils.JSONPatch(tcp, func() {
+			if len(tcp.Spec.DataStore) == 0 {
+				tcp.Spec.DataStore = t.DefaultDatastore
+			}
+			// When referring to a ControlPlaneSize, the replicas are resolved upon reconciliation.
+			if tcp.Spec.ControlPlane.Deployment.Replicas == nil && len(tcp.Spec.ControlPlane.Deployment.Size) == 0 {
+				tcp.Spec.ControlPlane.Deployment.Replicas = pointer.Int32(2)
+			}
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane creation")
 		}
 
-		return nil, nil
+		return operations, nil
 	}
 }
 
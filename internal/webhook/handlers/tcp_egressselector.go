@@ -0,0 +1,76 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneEgressSelector ensures the egress selections are not conflicting,
+// and the DataStore traffic is routed through Konnectivity only when served by an etcd DataStore:
+// the other drivers are reached by the API Server using the kine sidecar container.
+type TenantControlPlaneEgressSelector struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneEgressSelector) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneEgressSelector) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneEgressSelector) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneEgressSelector) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	names := map[kamajiv1alpha1.EgressSelectionName]struct{}{}
+
+	for _, selection := range tcp.Spec.Addons.Konnectivity.EgressSelections {
+		if _, ok := names[selection.Name]; ok {
+			return fmt.Errorf("the egress selection %s is declared more than once", selection.Name)
+		}
+
+		names[selection.Name] = struct{}{}
+
+		if selection.Name != kamajiv1alpha1.EgressSelectionEtcd || selection.Type != kamajiv1alpha1.EgressSelectionTypeKonnectivity {
+			continue
+		}
+
+		ds := &kamajiv1alpha1.DataStore{}
+		if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
+			return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane egress selections check, %w", err)
+		}
+
+		if ds.Spec.Driver != kamajiv1alpha1.EtcdDriver {
+			return fmt.Errorf("the etcd egress selection can be routed through Konnectivity only with an etcd DataStore, %s is using the %s driver", ds.GetName(), ds.Spec.Driver)
+		}
+	}
+
+	return nil
+}
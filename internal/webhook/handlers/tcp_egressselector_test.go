@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneEgressSelector(t *testing.T) {
+	handler := TenantControlPlaneEgressSelector{Client: networkPoolTestClient(t,
+		&kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}, Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.EtcdDriver}},
+		&kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "postgresql"}, Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KinePostgreSQLDriver}},
+	)}
+
+	tenant := func(dataStore string, selections ...kamajiv1alpha1.EgressSelection) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		tcp.Spec.DataStore = dataStore
+		tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{EgressSelections: selections}
+
+		return tcp
+	}
+
+	direct := kamajiv1alpha1.EgressSelection{Name: kamajiv1alpha1.EgressSelectionCluster, Type: kamajiv1alpha1.EgressSelectionTypeDirect}
+	etcd := kamajiv1alpha1.EgressSelection{Name: kamajiv1alpha1.EgressSelectionEtcd, Type: kamajiv1alpha1.EgressSelectionTypeKonnectivity}
+
+	tests := []struct {
+		name    string
+		tcp     *kamajiv1alpha1.TenantControlPlane
+		wantErr bool
+	}{
+		{name: "konnectivity disabled", tcp: &kamajiv1alpha1.TenantControlPlane{}},
+		{name: "defaults", tcp: tenant("postgresql")},
+		{name: "direct cluster", tcp: tenant("postgresql", direct)},
+		{name: "duplicated", tcp: tenant("postgresql", direct, direct), wantErr: true},
+		{name: "etcd through konnectivity", tcp: tenant("etcd", etcd)},
+		{name: "kine through konnectivity", tcp: tenant("postgresql", etcd), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.OnCreate(tt.tcp)(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneFlowControl struct{}
+
+func (t TenantControlPlaneFlowControl) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneFlowControl) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneFlowControl) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneFlowControl) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.Addons.FlowControl == nil {
+		return nil
+	}
+
+	if !addons.FlowControlSupported(tcp.Spec.Kubernetes.Version) {
+		return fmt.Errorf("the flow control addon requires Kubernetes v1.26 or greater, desired version is %s", tcp.Spec.Kubernetes.Version)
+	}
+
+	names := map[string]struct{}{}
+
+	for _, level := range tcp.Spec.Addons.FlowControl.PriorityLevels {
+		if _, ok := names[level.Name]; ok {
+			return fmt.Errorf("the flow control priority level %s is declared more than once", level.Name)
+		}
+
+		names[level.Name] = struct{}{}
+	}
+
+	return nil
+}
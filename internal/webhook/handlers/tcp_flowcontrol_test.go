@@ -0,0 +1,52 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneFlowControl(t *testing.T) {
+	tenant := func(version string, levels ...string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		tcp.Spec.Kubernetes.Version = version
+		tcp.Spec.Addons.FlowControl = &kamajiv1alpha1.FlowControlSpec{}
+
+		for _, level := range levels {
+			tcp.Spec.Addons.FlowControl.PriorityLevels = append(tcp.Spec.Addons.FlowControl.PriorityLevels, kamajiv1alpha1.FlowControlPriorityLevel{Name: level})
+		}
+
+		return tcp
+	}
+
+	tests := []struct {
+		name    string
+		tcp     *kamajiv1alpha1.TenantControlPlane
+		wantErr bool
+	}{
+		{name: "disabled", tcp: &kamajiv1alpha1.TenantControlPlane{}},
+		{name: "defaults", tcp: tenant("v1.26.1")},
+		{name: "custom levels", tcp: tenant("v1.27.0", "nodes", "operators")},
+		{name: "unsupported version", tcp: tenant("v1.25.3"), wantErr: true},
+		{name: "duplicated levels", tcp: tenant("v1.26.1", "nodes", "nodes"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TenantControlPlaneFlowControl{}.OnCreate(tt.tcp)(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneIdlePolicy struct{}
+
+func (t TenantControlPlaneIdlePolicy) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp.Spec.ControlPlane.IdlePolicy)
+	}
+}
+
+func (t TenantControlPlaneIdlePolicy) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneIdlePolicy) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp.Spec.ControlPlane.IdlePolicy)
+	}
+}
+
+func (t TenantControlPlaneIdlePolicy) validate(policy *kamajiv1alpha1.IdlePolicySpec) error {
+	if policy == nil {
+		return nil
+	}
+
+	if policy.IdleTimeout.Duration <= 0 {
+		return fmt.Errorf("the idle timeout must be greater than zero")
+	}
+
+	return nil
+}
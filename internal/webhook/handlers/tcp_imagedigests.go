@@ -0,0 +1,138 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmv1beta3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta3"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	kubeadmimages "k8s.io/kubernetes/cmd/kubeadm/app/images"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/images"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneImageDigests is pinning the images rendered for the Tenant Control Plane to the digests
+// of the local mirror manifest index, tracking them in an annotation: the pinned digests are retained
+// across the updates, and the ones of the images no more rendered, e.g. upon a version upgrade, are replaced.
+type TenantControlPlaneImageDigests struct {
+	Images       images.Policy
+	KineImage    string
+	MigrateImage string
+}
+
+func (t TenantControlPlaneImageDigests) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.pin(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneImageDigests) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneImageDigests) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.pin(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneImageDigests) pin(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]jsonpatch.JsonPatchOperation, error) {
+	if len(t.Images.Digests) == 0 {
+		return nil, nil
+	}
+
+	resolved, unresolved := t.Images.Pin(images.Pinned(tcp), t.images(tcp))
+	if len(unresolved) > 0 {
+		log.FromContext(ctx).Info("images missing from the digests index, falling back to the tags", "images", unresolved)
+	}
+
+	value, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode the Tenant Control Plane image digests")
+	}
+
+	if tcp.GetAnnotations()[constants.ImageDigests] == string(value) {
+		return nil, nil
+	}
+
+	operations, err := utils.JSONPatch(tcp, func() {
+		annotations := tcp.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[constants.ImageDigests] = string(value)
+
+		tcp.SetAnnotations(annotations)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane image digests pinning")
+	}
+
+	return operations, nil
+}
+
+// images returns the images rendered by Kamaji for the given Tenant Control Plane, before the registry rewrite:
+// the Kine and migration ones are always pinned, since the DataStore can be changed at any time.
+func (t TenantControlPlaneImageDigests) images(tcp *kamajiv1alpha1.TenantControlPlane) []string {
+	version, registrySettings := tcp.Spec.Kubernetes.Version, tcp.Spec.ControlPlane.Deployment.RegistrySettings
+
+	rendered := []string{
+		registrySettings.KubeAPIServerImage(version),
+		registrySettings.KubeControllerManagerImage(version),
+		registrySettings.KubeSchedulerImage(version),
+		t.KineImage,
+		t.MigrateImage,
+	}
+
+	if konnectivity := tcp.Spec.Addons.Konnectivity; konnectivity != nil {
+		rendered = append(rendered,
+			fmt.Sprintf("%s:%s", konnectivity.KonnectivityServerSpec.Image, konnectivity.KonnectivityServerSpec.Version),
+			fmt.Sprintf("%s:%s", konnectivity.KonnectivityAgentSpec.Image, konnectivity.KonnectivityAgentSpec.Version),
+		)
+	}
+	// The addons images are computed by kubeadm, matching the overrides applied upon the manifests generation.
+	if coreDNS := tcp.Spec.Addons.CoreDNS; coreDNS != nil {
+		config := &kubeadmapi.ClusterConfiguration{ImageRepository: kubeadmv1beta3.DefaultImageRepository}
+
+		if len(coreDNS.ImageRepository) > 0 {
+			config.DNS.ImageRepository = coreDNS.ImageRepository
+			config.DNS.ImageTag = coreDNS.ImageTag
+		}
+
+		rendered = append(rendered, kubeadmimages.GetDNSImage(config))
+	}
+
+	if kubeProxy := tcp.Spec.Addons.KubeProxy; kubeProxy != nil {
+		config := &kubeadmapi.ClusterConfiguration{ImageRepository: kubeadmv1beta3.DefaultImageRepository, KubernetesVersion: version}
+
+		if len(kubeProxy.ImageRepository) > 0 {
+			config.ImageRepository = kubeProxy.ImageRepository
+		}
+
+		if len(kubeProxy.ImageTag) > 0 {
+			config.KubernetesVersion = kubeProxy.ImageTag
+		}
+
+		rendered = append(rendered, kubeadmimages.GetKubernetesImage(kubeadmconstants.KubeProxy, config))
+	}
+
+	return rendered
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/images"
+)
+
+func TestTenantControlPlaneImageDigests(t *testing.T) {
+	apiServerDigest, coreDNSDigest, pinnedDigest := "sha256:"+strings.Repeat("a", 64), "sha256:"+strings.Repeat("b", 64), "sha256:"+strings.Repeat("c", 64)
+
+	handler := TenantControlPlaneImageDigests{
+		Images: images.Policy{
+			Rules: []images.Rule{{Prefix: "registry.k8s.io", Replacement: "mirror.local"}},
+			Digests: map[string]string{
+				"mirror.local/kube-apiserver:v1.26.1":                             apiServerDigest,
+				"mirror.local/kube-scheduler:v1.26.1":                             apiServerDigest,
+				"mirror.local/coredns/coredns:" + kubeadmconstants.CoreDNSVersion: coreDNSDigest,
+				"mirror.local/kube-controller-manager:v1.26.1":                    apiServerDigest,
+				"mirror.local/kube-apiserver:v1.25.0":                             apiServerDigest,
+				"mirror.local/kas-network-proxy/proxy-server:v0.0.32":             apiServerDigest,
+			},
+		},
+		KineImage:    "rancher/kine:v0.9.2-amd64",
+		MigrateImage: "clastix/kamaji:latest",
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Spec.Kubernetes.Version = "v1.26.1"
+	tcp.Spec.ControlPlane.Deployment.RegistrySettings = kamajiv1alpha1.RegistrySettings{
+		Registry:               "registry.k8s.io",
+		APIServerImage:         "kube-apiserver",
+		ControllerManagerImage: "kube-controller-manager",
+		SchedulerImage:         "kube-scheduler",
+	}
+	tcp.Spec.Addons.CoreDNS = &kamajiv1alpha1.AddonSpec{}
+	// The scheduler digest has been pinned upon a previous admission, and must be retained.
+	tcp.SetAnnotations(map[string]string{constants.ImageDigests: `{"mirror.local/kube-scheduler:v1.26.1":"` + pinnedDigest + `"}`})
+
+	operations, err := handler.OnUpdate(tcp, tcp)(context.Background(), admission.Request{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(operations) != 1 {
+		t.Fatalf("expected a single patch operation, got %v", operations)
+	}
+
+	actual := map[string]string{}
+	if err = json.Unmarshal([]byte(operations[0].Value.(string)), &actual); err != nil { //nolint:forcetypeassert
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"mirror.local/kube-apiserver:v1.26.1":                             apiServerDigest,
+		"mirror.local/kube-controller-manager:v1.26.1":                    apiServerDigest,
+		"mirror.local/kube-scheduler:v1.26.1":                             pinnedDigest,
+		"mirror.local/coredns/coredns:" + kubeadmconstants.CoreDNSVersion: coreDNSDigest,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	// Once pinned, the subsequent admissions are not patching the Tenant Control Plane.
+	tcp.SetAnnotations(map[string]string{constants.ImageDigests: operations[0].Value.(string)}) //nolint:forcetypeassert
+
+	if operations, err = handler.OnUpdate(tcp, tcp)(context.Background(), admission.Request{}); err != nil || len(operations) != 0 {
+		t.Fatalf("expected no patch operations, got %v (%v)", operations, err)
+	}
+	// Without a digests index, the images are not pinned.
+	if operations, err = (TenantControlPlaneImageDigests{}).OnCreate(tcp)(context.Background(), admission.Request{}); err != nil || len(operations) != 0 {
+		t.Fatalf("expected no patch operations, got %v (%v)", operations, err)
+	}
+}
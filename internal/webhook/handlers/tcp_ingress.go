@@ -0,0 +1,95 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneIngress struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneIngress) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneIngress) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneIngress) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneIngress) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.ControlPlane.Ingress == nil {
+		return nil
+	}
+
+	hostname := ingressHostname(tcp)
+
+	if len(hostname) == 0 {
+		return fmt.Errorf("the Ingress hostname is required, since no Ingress base domain has been configured")
+	}
+
+	if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return fmt.Errorf("the Ingress hostname %s is not valid: %s", hostname, strings.Join(errs, ", "))
+	}
+
+	return t.checkCollisions(ctx, tcp, hostname)
+}
+
+// checkCollisions ensures the Ingress hostname is not already used by other Tenant Control Planes.
+func (t TenantControlPlaneIngress) checkCollisions(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, hostname string) error {
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := t.Client.List(ctx, tcpList); err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane Ingress check, %w", err)
+	}
+
+	for i := range tcpList.Items {
+		item := tcpList.Items[i]
+
+		if item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName() {
+			continue
+		}
+
+		if ingressHostname(&item) == hostname {
+			return fmt.Errorf("the Ingress hostname %s is already used by the Tenant Control Plane %s/%s", hostname, item.GetNamespace(), item.GetName())
+		}
+	}
+
+	return nil
+}
+
+// ingressHostname returns the lower-cased Ingress hostname of the Tenant Control Plane, without the port.
+func ingressHostname(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	if tcp.Spec.ControlPlane.Ingress == nil {
+		return ""
+	}
+
+	hostname, _ := utilities.GetControlPlaneAddressAndPortFromHostname(tcp.Spec.ControlPlane.Ingress.Hostname, 0)
+
+	return strings.ToLower(hostname)
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneIngressDefaults is defaulting the hostname of the Tenant Control Planes exposed using an Ingress,
+// deriving it from the base domain as "<name>.<namespace>.<base domain>".
+type TenantControlPlaneIngressDefaults struct {
+	BaseDomain string
+}
+
+func (t TenantControlPlaneIngressDefaults) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.defaults(tcp)
+	}
+}
+
+func (t TenantControlPlaneIngressDefaults) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneIngressDefaults) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.defaults(tcp)
+	}
+}
+
+func (t TenantControlPlaneIngressDefaults) defaults(tcp *kamajiv1alpha1.TenantControlPlane) ([]jsonpatch.JsonPatchOperation, error) {
+	ingress := tcp.Spec.ControlPlane.Ingress
+	if ingress == nil || len(ingress.Hostname) > 0 || len(t.BaseDomain) == 0 {
+		return nil, nil
+	}
+
+	operations, err := utils.JSONPatch(tcp, func() {
+		ingress.Hostname = fmt.Sprintf("%s.%s.%s", tcp.GetName(), tcp.GetNamespace(), strings.Trim(t.BaseDomain, "."))
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane Ingress defaulting")
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func ingressTenantControlPlane(namespace, name, hostname string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+	tcp.Spec.ControlPlane.Ingress = &kamajiv1alpha1.IngressSpec{Hostname: hostname}
+
+	return tcp
+}
+
+func TestTenantControlPlaneIngressDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseDomain string
+		hostname   string
+		expected   string
+	}{
+		{name: "generated", baseDomain: "tenants.example.com", expected: "tenant-00.default.tenants.example.com"},
+		{name: "generated with trailing dot", baseDomain: ".tenants.example.com.", expected: "tenant-00.default.tenants.example.com"},
+		{name: "declared", baseDomain: "tenants.example.com", hostname: "api.example.com", expected: "api.example.com"},
+		{name: "no base domain", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := ingressTenantControlPlane("default", "tenant-00", tt.hostname)
+
+			if _, err := (TenantControlPlaneIngressDefaults{BaseDomain: tt.baseDomain}).OnCreate(tcp)(context.Background(), admission.Request{}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tcp.Spec.ControlPlane.Ingress.Hostname != tt.expected {
+				t.Fatalf("expected hostname %q, got %q", tt.expected, tcp.Spec.ControlPlane.Ingress.Hostname)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneIngressValidation(t *testing.T) {
+	existing := ingressTenantControlPlane("default", "existing", "tenant.example.com:443")
+
+	tests := []struct {
+		name    string
+		tcp     *kamajiv1alpha1.TenantControlPlane
+		wantErr bool
+	}{
+		{name: "no ingress", tcp: &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}},
+		{name: "unique hostname", tcp: ingressTenantControlPlane("default", "tenant-00", "other.example.com")},
+		{name: "missing hostname", tcp: ingressTenantControlPlane("default", "tenant-00", ""), wantErr: true},
+		{name: "invalid hostname", tcp: ingressTenantControlPlane("default", "tenant-00", "Tenant_00"), wantErr: true},
+		{name: "colliding hostname", tcp: ingressTenantControlPlane("other", "tenant-00", "TENANT.example.com"), wantErr: true},
+		{name: "same Tenant Control Plane", tcp: ingressTenantControlPlane("default", "existing", "tenant.example.com")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := TenantControlPlaneIngress{Client: networkPoolTestClient(t, existing)}
+
+			_, err := handler.OnCreate(tt.tcp)(context.Background(), admission.Request{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
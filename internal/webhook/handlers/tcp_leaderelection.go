@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// Leader election defaults of the controller-manager and the scheduler,
+// used to validate the partially declared settings.
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+type TenantControlPlaneLeaderElection struct{}
+
+func (t TenantControlPlaneLeaderElection) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneLeaderElection) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneLeaderElection) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneLeaderElection) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	leaderElection := tcp.Spec.ControlPlane.Deployment.LeaderElection
+	if leaderElection == nil {
+		return nil
+	}
+
+	duration := func(value *metav1.Duration, defaultValue time.Duration) time.Duration {
+		if value == nil {
+			return defaultValue
+		}
+
+		return value.Duration
+	}
+
+	leaseDuration := duration(leaderElection.LeaseDuration, defaultLeaseDuration)
+	renewDeadline := duration(leaderElection.RenewDeadline, defaultRenewDeadline)
+	retryPeriod := duration(leaderElection.RetryPeriod, defaultRetryPeriod)
+
+	switch {
+	case retryPeriod <= 0:
+		return fmt.Errorf("the leader election retry period must be greater than zero")
+	case renewDeadline <= retryPeriod:
+		return fmt.Errorf("the leader election renew deadline (%s) must be greater than the retry period (%s)", renewDeadline, retryPeriod)
+	case leaseDuration <= renewDeadline:
+		return fmt.Errorf("the leader election lease duration (%s) must be greater than the renew deadline (%s)", leaseDuration, renewDeadline)
+	}
+
+	return nil
+}
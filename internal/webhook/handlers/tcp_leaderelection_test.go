@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneLeaderElection(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration {
+		return &metav1.Duration{Duration: d}
+	}
+
+	tests := []struct {
+		name           string
+		leaderElection *kamajiv1alpha1.LeaderElectionSpec
+		wantErr        bool
+	}{
+		{name: "defaults"},
+		{name: "all declared", leaderElection: &kamajiv1alpha1.LeaderElectionSpec{LeaseDuration: duration(60 * time.Second), RenewDeadline: duration(40 * time.Second), RetryPeriod: duration(5 * time.Second)}},
+		{name: "longer lease", leaderElection: &kamajiv1alpha1.LeaderElectionSpec{LeaseDuration: duration(30 * time.Second)}},
+		{name: "lease shorter than default renew deadline", leaderElection: &kamajiv1alpha1.LeaderElectionSpec{LeaseDuration: duration(5 * time.Second)}, wantErr: true},
+		{name: "renew deadline shorter than retry", leaderElection: &kamajiv1alpha1.LeaderElectionSpec{RenewDeadline: duration(time.Second)}, wantErr: true},
+		{name: "zero retry", leaderElection: &kamajiv1alpha1.LeaderElectionSpec{RetryPeriod: duration(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.ControlPlane.Deployment.LeaderElection = tt.leaderElection
+
+			_, err := TenantControlPlaneLeaderElection{}.OnCreate(tcp)(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
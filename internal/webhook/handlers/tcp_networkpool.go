@@ -0,0 +1,317 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/networkpool"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+const (
+	defaultAPIServerPort    int32 = 6443
+	defaultKonnectivityPort int32 = 8132
+)
+
+// TenantControlPlaneNetworkPool is allocating the address, and the API Server and Konnectivity server ports,
+// of the Tenant Control Planes leaving them empty: the values are reserved in the status of the referred NetworkPool,
+// or the default one, and released by the NetworkPool controller once the Tenant Control Plane is deleted.
+// When no pool is available, the ports are defaulted to the well-known ones.
+type TenantControlPlaneNetworkPool struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneNetworkPool) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.allocate(ctx, tcp, pointer.BoolDeref(req.DryRun, false))
+	}
+}
+
+func (t TenantControlPlaneNetworkPool) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneNetworkPool) OnUpdate(object runtime.Object, prevObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), prevObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		// The allocated values are persisted in the specification: when dropped by a client
+		// not aware of them (e.g. applying the original manifest) the previous ones are retained.
+		operations, err := utils.JSONPatch(newTCP, func() {
+			if newTCP.Spec.NetworkProfile.Port == 0 {
+				newTCP.Spec.NetworkProfile.Port = oldTCP.Spec.NetworkProfile.Port
+			}
+
+			if newKonnectivity, oldKonnectivity := newTCP.Spec.Addons.Konnectivity, oldTCP.Spec.Addons.Konnectivity; newKonnectivity != nil && oldKonnectivity != nil && newKonnectivity.KonnectivityServerSpec.Port == 0 {
+				newKonnectivity.KonnectivityServerSpec.Port = oldKonnectivity.KonnectivityServerSpec.Port
+			}
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane network pool allocation")
+		}
+
+		allocated, err := t.allocate(ctx, newTCP, pointer.BoolDeref(req.DryRun, false))
+		if err != nil {
+			return nil, err
+		}
+
+		return append(operations, allocated...), nil
+	}
+}
+
+func (t TenantControlPlaneNetworkPool) allocate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, dryRun bool) ([]jsonpatch.JsonPatchOperation, error) {
+	konnectivity := tcp.Spec.Addons.Konnectivity
+
+	needsAddress := len(tcp.Spec.NetworkProfile.Address) == 0 && tcp.Spec.ControlPlane.SNIProxy == nil && tcp.Spec.ControlPlane.Service.ServiceType != kamajiv1alpha1.ServiceTypeLoadBalancer
+	needsPort := tcp.Spec.NetworkProfile.Port == 0
+	needsKonnectivityPort := konnectivity != nil && konnectivity.KonnectivityServerSpec.Port == 0
+
+	if !needsAddress && !needsPort && !needsKonnectivityPort {
+		return nil, nil
+	}
+
+	pool, err := t.pool(ctx, tcp)
+	if err != nil {
+		return nil, err
+	}
+
+	var address string
+
+	var ports []int32
+
+	if pool != nil {
+		if address, ports, err = t.reserve(ctx, pool.GetName(), tcp, needsAddress, needsPort, needsKonnectivityPort, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	operations, err := utils.JSONPatch(tcp, func() {
+		if len(address) > 0 {
+			tcp.Spec.NetworkProfile.Address = address
+		}
+
+		if needsPort {
+			tcp.Spec.NetworkProfile.Port, ports = nextAllocatedPort(ports, defaultAPIServerPort)
+		}
+
+		if needsKonnectivityPort {
+			konnectivity.KonnectivityServerSpec.Port, _ = nextAllocatedPort(ports, defaultKonnectivityPort)
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane network pool allocation")
+	}
+
+	return operations, nil
+}
+
+// nextAllocatedPort pops the first allocated port, falling back to the given default one.
+func nextAllocatedPort(ports []int32, defaultPort int32) (int32, []int32) {
+	if len(ports) == 0 {
+		return defaultPort, nil
+	}
+
+	return ports[0], ports[1:]
+}
+
+// pool returns the NetworkPool referred by the Tenant Control Plane, or the default one:
+// nil is returned when no default pool exists.
+func (t TenantControlPlaneNetworkPool) pool(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.NetworkPool, error) {
+	if name := tcp.Spec.NetworkProfile.Pool; len(name) > 0 {
+		pool := &kamajiv1alpha1.NetworkPool{}
+		if err := t.Client.Get(ctx, k8stypes.NamespacedName{Name: name}, pool); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the NetworkPool %s", name))
+		}
+
+		return pool, nil
+	}
+
+	pools := &kamajiv1alpha1.NetworkPoolList{}
+	if err := t.Client.List(ctx, pools); err != nil {
+		return nil, errors.Wrap(err, "cannot list the NetworkPools")
+	}
+
+	var defaultPool *kamajiv1alpha1.NetworkPool
+
+	for i := range pools.Items {
+		if !pools.Items[i].Spec.Default {
+			continue
+		}
+
+		if defaultPool != nil {
+			return nil, fmt.Errorf("multiple default NetworkPools found, %s and %s", defaultPool.GetName(), pools.Items[i].GetName())
+		}
+
+		defaultPool = &pools.Items[i]
+	}
+
+	return defaultPool, nil
+}
+
+// reserve stores the allocation of the Tenant Control Plane in the NetworkPool status, returning the newly allocated
+// address, if required, and the ports, in order: the API Server first, and then the Konnectivity server one.
+// The values already reserved for the Tenant Control Plane are reused, and the ones still in use are retained.
+func (t TenantControlPlaneNetworkPool) reserve(ctx context.Context, poolName string, tcp *kamajiv1alpha1.TenantControlPlane, needsAddress, needsPort, needsKonnectivityPort, dryRun bool) (string, []int32, error) {
+	var address string
+
+	var ports []int32
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		address, ports = "", nil
+
+		pool := &kamajiv1alpha1.NetworkPool{}
+		if err := t.Client.Get(ctx, k8stypes.NamespacedName{Name: poolName}, pool); err != nil {
+			return err
+		}
+
+		usedAddresses, usedPorts, err := t.used(ctx, pool, tcp)
+		if err != nil {
+			return err
+		}
+
+		index, previous := -1, kamajiv1alpha1.NetworkPoolAllocation{}
+
+		for i, current := range pool.Status.Allocations {
+			if current.Namespace == tcp.GetNamespace() && current.Name == tcp.GetName() {
+				index, previous = i, current
+
+				break
+			}
+		}
+
+		allocation := kamajiv1alpha1.NetworkPoolAllocation{
+			Namespace:   tcp.GetNamespace(),
+			Name:        tcp.GetName(),
+			AllocatedAt: metav1.Now(),
+		}
+
+		switch {
+		case !needsAddress && previous.Address == tcp.Spec.NetworkProfile.Address:
+			allocation.Address = previous.Address
+		case needsAddress && len(previous.Address) > 0:
+			allocation.Address, address = previous.Address, previous.Address
+		case needsAddress && len(pool.Spec.Addresses) > 0:
+			if address, err = networkpool.NextAddress(pool.Spec.Addresses, usedAddresses); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot allocate an address from the NetworkPool %s", pool.GetName()))
+			}
+
+			allocation.Address = address
+		}
+		// The previously allocated ports not used anymore by the Tenant Control Plane are the first candidates.
+		inUse, candidates := networkpool.TenantControlPlanePorts(tcp), []int32{}
+
+		for _, port := range previous.Ports {
+			switch {
+			case inUse.Has(port):
+				allocation.Ports = append(allocation.Ports, port)
+			case !usedPorts.Has(port):
+				candidates = append(candidates, port)
+			}
+		}
+
+		usedPorts.Insert(inUse.UnsortedList()...)
+
+		required := 0
+		if needsPort {
+			required++
+		}
+
+		if needsKonnectivityPort {
+			required++
+		}
+
+		for len(pool.Spec.Ports) > 0 && len(ports) < required {
+			var port int32
+
+			if len(candidates) > 0 {
+				port, candidates = candidates[0], candidates[1:]
+			} else if port, err = networkpool.NextPort(pool.Spec.Ports, usedPorts); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot allocate a port from the NetworkPool %s", pool.GetName()))
+			}
+
+			usedPorts.Insert(port)
+
+			ports = append(ports, port)
+		}
+
+		allocation.Ports = append(allocation.Ports, ports...)
+
+		switch {
+		case len(allocation.Address) == 0 && len(allocation.Ports) == 0 && index < 0:
+			return nil
+		case len(allocation.Address) == 0 && len(allocation.Ports) == 0:
+			pool.Status.Allocations = append(pool.Status.Allocations[:index], pool.Status.Allocations[index+1:]...)
+		case index >= 0:
+			pool.Status.Allocations[index] = allocation
+		default:
+			pool.Status.Allocations = append(pool.Status.Allocations, allocation)
+		}
+
+		var opts []client.SubResourceUpdateOption
+		if dryRun {
+			opts = append(opts, client.DryRunAll)
+		}
+
+		return t.Client.Status().Update(ctx, pool, opts...)
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "cannot reserve the Tenant Control Plane network allocation")
+	}
+
+	return address, ports, nil
+}
+
+// used returns the addresses, and the ports, already allocated by the pool to other Tenant Control Planes,
+// along with the ones statically declared by them.
+func (t TenantControlPlaneNetworkPool) used(ctx context.Context, pool *kamajiv1alpha1.NetworkPool, tcp *kamajiv1alpha1.TenantControlPlane) (sets.String, sets.Int32, error) {
+	addresses, ports := sets.NewString(), sets.NewInt32()
+
+	for _, allocation := range pool.Status.Allocations {
+		if allocation.Namespace == tcp.GetNamespace() && allocation.Name == tcp.GetName() {
+			continue
+		}
+
+		if len(allocation.Address) > 0 {
+			addresses.Insert(allocation.Address)
+		}
+
+		ports.Insert(allocation.Ports...)
+	}
+
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := t.Client.List(ctx, tcpList); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot list the Tenant Control Planes")
+	}
+
+	for i := range tcpList.Items {
+		item := tcpList.Items[i]
+
+		if item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName() {
+			continue
+		}
+
+		if len(item.Spec.NetworkProfile.Address) > 0 {
+			addresses.Insert(item.Spec.NetworkProfile.Address)
+		}
+
+		ports.Insert(networkpool.TenantControlPlanePorts(&tcpList.Items[i]).UnsortedList()...)
+	}
+
+	return addresses, ports, nil
+}
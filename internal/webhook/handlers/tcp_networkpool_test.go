@@ -0,0 +1,135 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func networkPoolTestClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func nodePortTenantControlPlane(name string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+	}
+	tcp.Spec.ControlPlane.Service.ServiceType = kamajiv1alpha1.ServiceTypeNodePort
+	tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{}
+
+	return tcp
+}
+
+func TestTenantControlPlaneNetworkPoolDefaults(t *testing.T) {
+	tcp := nodePortTenantControlPlane("tenant-00")
+
+	handler := TenantControlPlaneNetworkPool{Client: networkPoolTestClient(t)}
+
+	if _, err := handler.OnCreate(tcp)(context.Background(), admission.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if tcp.Spec.NetworkProfile.Port != defaultAPIServerPort || tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port != defaultKonnectivityPort {
+		t.Fatalf("expected default ports, got %d and %d", tcp.Spec.NetworkProfile.Port, tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+	}
+
+	if len(tcp.Spec.NetworkProfile.Address) > 0 {
+		t.Fatalf("expected no address, got %s", tcp.Spec.NetworkProfile.Address)
+	}
+}
+
+func TestTenantControlPlaneNetworkPoolAllocation(t *testing.T) {
+	pool := &kamajiv1alpha1.NetworkPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: kamajiv1alpha1.NetworkPoolSpec{
+			Default:   true,
+			Addresses: []string{"10.0.0.0/29"},
+			Ports:     []kamajiv1alpha1.PortRange{{From: 31000, To: 31010}},
+		},
+	}
+	// The existing Tenant Control Plane is using statically declared values included in the pool.
+	existing := nodePortTenantControlPlane("existing")
+	existing.Spec.NetworkProfile.Address = "10.0.0.1"
+	existing.Spec.NetworkProfile.Port = 31000
+	existing.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port = 31001
+
+	c := networkPoolTestClient(t, pool, existing)
+	handler := TenantControlPlaneNetworkPool{Client: c}
+
+	tcp := nodePortTenantControlPlane("tenant-00")
+	if _, err := handler.OnCreate(tcp)(context.Background(), admission.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if tcp.Spec.NetworkProfile.Address != "10.0.0.2" || tcp.Spec.NetworkProfile.Port != 31002 || tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port != 31003 {
+		t.Fatalf("unexpected allocation, got %s:%d and %d", tcp.Spec.NetworkProfile.Address, tcp.Spec.NetworkProfile.Port, tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+	}
+
+	if err := c.Get(context.Background(), k8stypes.NamespacedName{Name: pool.GetName()}, pool); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pool.Status.Allocations) != 1 {
+		t.Fatalf("expected a single allocation, got %d", len(pool.Status.Allocations))
+	}
+
+	if allocation := pool.Status.Allocations[0]; allocation.Name != "tenant-00" || allocation.Address != "10.0.0.2" || len(allocation.Ports) != 2 {
+		t.Fatalf("unexpected allocation %+v", allocation)
+	}
+	// A second admission of the same Tenant Control Plane, e.g. upon a retry, reuses the reserved values.
+	retried := nodePortTenantControlPlane("tenant-00")
+	if _, err := handler.OnCreate(retried)(context.Background(), admission.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if retried.Spec.NetworkProfile.Address != "10.0.0.2" || retried.Spec.NetworkProfile.Port != 31002 || retried.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port != 31003 {
+		t.Fatalf("expected the reserved values to be reused, got %s:%d and %d", retried.Spec.NetworkProfile.Address, retried.Spec.NetworkProfile.Port, retried.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+	}
+}
+
+func TestTenantControlPlaneNetworkPoolUpdateRetainsPorts(t *testing.T) {
+	oldTCP := nodePortTenantControlPlane("tenant-00")
+	oldTCP.Spec.NetworkProfile.Port = 31002
+	oldTCP.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port = 31003
+
+	newTCP := nodePortTenantControlPlane("tenant-00")
+
+	handler := TenantControlPlaneNetworkPool{Client: networkPoolTestClient(t)}
+
+	if _, err := handler.OnUpdate(newTCP, oldTCP)(context.Background(), admission.Request{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if newTCP.Spec.NetworkProfile.Port != 31002 || newTCP.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port != 31003 {
+		t.Fatalf("expected the previous ports to be retained, got %d and %d", newTCP.Spec.NetworkProfile.Port, newTCP.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+	}
+}
+
+func TestTenantControlPlaneNetworkPoolMultipleDefaults(t *testing.T) {
+	handler := TenantControlPlaneNetworkPool{Client: networkPoolTestClient(t,
+		&kamajiv1alpha1.NetworkPool{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: kamajiv1alpha1.NetworkPoolSpec{Default: true}},
+		&kamajiv1alpha1.NetworkPool{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: kamajiv1alpha1.NetworkPoolSpec{Default: true}},
+	)}
+
+	if _, err := handler.OnCreate(nodePortTenantControlPlane("tenant-00"))(context.Background(), admission.Request{}); err == nil {
+		t.Fatal("expected an error with multiple default pools")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneNetworkProfile struct {
+	// NodePortRange is the NodePort range of the Management Cluster, the declared NodePort must be included in.
+	NodePortRange utilnet.PortRange
+}
+
+func (t TenantControlPlaneNetworkProfile) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneNetworkProfile) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneNetworkProfile) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneNetworkProfile) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.NetworkProfile.NodePort > 0 && tcp.Spec.ControlPlane.Service.ServiceType != kamajiv1alpha1.ServiceTypeNodePort {
+		return fmt.Errorf("the NodePort can be set only when the Tenant Control Plane is exposed using a %s Service", kamajiv1alpha1.ServiceTypeNodePort)
+	}
+
+	if nodePort := tcp.Spec.NetworkProfile.NodePort; nodePort > 0 && t.NodePortRange.Size > 0 && !t.NodePortRange.Contains(int(nodePort)) {
+		return fmt.Errorf("the NodePort %d is not included in the NodePort range %s", nodePort, t.NodePortRange.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneNetworkProfileValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceType kamajiv1alpha1.ServiceType
+		nodePort    int32
+		wantErr     bool
+	}{
+		{name: "NodePort Service", serviceType: kamajiv1alpha1.ServiceTypeNodePort, nodePort: 31443},
+		{name: "LoadBalancer Service", serviceType: kamajiv1alpha1.ServiceTypeLoadBalancer},
+		{name: "NodePort with a LoadBalancer Service", serviceType: kamajiv1alpha1.ServiceTypeLoadBalancer, nodePort: 31443, wantErr: true},
+		{name: "NodePort with a ClusterIP Service", serviceType: kamajiv1alpha1.ServiceTypeClusterIP, nodePort: 31443, wantErr: true},
+		{name: "NodePort out of range", serviceType: kamajiv1alpha1.ServiceTypeNodePort, nodePort: 6443, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.ControlPlane.Service.ServiceType = tt.serviceType
+			tcp.Spec.NetworkProfile.NodePort = tt.nodePort
+
+			_, err := TenantControlPlaneNetworkProfile{NodePortRange: utilnet.PortRange{Base: 30000, Size: 2768}}.OnCreate(tcp)(context.Background(), admission.Request{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
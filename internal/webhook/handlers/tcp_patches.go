@@ -0,0 +1,126 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlanePatches ensures the patches can be applied to the resources generated for the Tenant Control Plane:
+// the existing resources are used, otherwise, the desired ones are computed.
+type TenantControlPlanePatches struct {
+	Client            client.Client
+	DeploymentBuilder controlplane.Deployment
+}
+
+func (t TenantControlPlanePatches) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlanePatches) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlanePatches) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlanePatches) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	kinds := map[kamajiv1alpha1.PatchTargetKind]struct{}{}
+
+	for i, patch := range tcp.Spec.Patches {
+		if len(patch.Target.Name) > 0 && patch.Target.Name != tcp.GetName() {
+			return fmt.Errorf("the patch %d is targeting the %s %s, although the one generated for the Tenant Control Plane is named %s", i, patch.Target.Kind, patch.Target.Name, tcp.GetName())
+		}
+
+		kinds[patch.Target.Kind] = struct{}{}
+	}
+
+	for kind := range kinds {
+		object, err := t.desiredObject(ctx, tcp, kind)
+		if err != nil {
+			return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane patches check, %w", err)
+		}
+
+		if err = utilities.ApplyPatches(object, kind, tcp.Spec.Patches); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// desiredObject returns the existing resource of the given kind, or the desired one when not yet created.
+func (t TenantControlPlanePatches) desiredObject(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, kind kamajiv1alpha1.PatchTargetKind) (client.Object, error) {
+	meta := metav1.ObjectMeta{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}
+	selector := map[string]string{"kamaji.clastix.io/name": tcp.GetName()}
+
+	var object client.Object
+
+	switch kind {
+	case kamajiv1alpha1.PatchTargetDeployment:
+		object = &appsv1.Deployment{ObjectMeta: meta}
+	case kamajiv1alpha1.PatchTargetService:
+		object = &corev1.Service{
+			ObjectMeta: meta,
+			Spec: corev1.ServiceSpec{
+				Selector: selector,
+				Ports:    []corev1.ServicePort{{Name: "kube-apiserver", Protocol: corev1.ProtocolTCP, Port: tcp.Spec.NetworkProfile.GetServicePort()}},
+				Type:     corev1.ServiceType(tcp.Spec.ControlPlane.Service.ServiceType),
+			},
+		}
+	case kamajiv1alpha1.PatchTargetIngress:
+		object = &networkingv1.Ingress{ObjectMeta: meta}
+	default:
+		return nil, fmt.Errorf("unsupported patch target kind %s", kind)
+	}
+
+	if err := t.Client.Get(ctx, types.NamespacedName{Namespace: meta.Namespace, Name: meta.Name}, object); err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if deployment, ok := object.(*appsv1.Deployment); ok {
+		ds := kamajiv1alpha1.DataStore{}
+		if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, &ds); err != nil {
+			return nil, err
+		}
+
+		size, err := tcp.GetControlPlaneSize(ctx, t.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		builder := t.DeploymentBuilder
+		builder.DataStore = ds
+		builder.Size = size
+		builder.Build(ctx, deployment, *tcp)
+	}
+
+	return object, nil
+}
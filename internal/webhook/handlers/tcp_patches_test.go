@@ -0,0 +1,98 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func patchesTestClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestTenantControlPlanePatches(t *testing.T) {
+	existing := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"kamaji.clastix.io/name": "existing"},
+			Ports:    []corev1.ServicePort{{Name: "kube-apiserver", Protocol: corev1.ProtocolTCP, Port: 6443}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		tcp     string
+		patch   kamajiv1alpha1.Patch
+		wantErr bool
+	}{
+		{
+			name:  "desired Service",
+			tcp:   "tenant-00",
+			patch: kamajiv1alpha1.Patch{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService}, Type: kamajiv1alpha1.PatchTypeStrategicMerge, Patch: `{"spec": {"externalTrafficPolicy": "Local"}}`},
+		},
+		{
+			name:  "existing Service",
+			tcp:   "existing",
+			patch: kamajiv1alpha1.Patch{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService, Name: "existing"}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: `[{"op": "replace", "path": "/spec/ports/0/port", "value": 443}]`},
+		},
+		{
+			name:    "mismatching name",
+			tcp:     "tenant-00",
+			patch:   kamajiv1alpha1.Patch{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService, Name: "other"}, Type: kamajiv1alpha1.PatchTypeStrategicMerge, Patch: `{"spec": {"externalTrafficPolicy": "Local"}}`},
+			wantErr: true,
+		},
+		{
+			name:    "not applying cleanly",
+			tcp:     "tenant-00",
+			patch:   kamajiv1alpha1.Patch{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetIngress}, Type: kamajiv1alpha1.PatchTypeJSON, Patch: `[{"op": "replace", "path": "/spec/rules/0/host", "value": "tenant.example.com"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "protected selector",
+			tcp:     "existing",
+			patch:   kamajiv1alpha1.Patch{Target: kamajiv1alpha1.PatchTarget{Kind: kamajiv1alpha1.PatchTargetService}, Type: kamajiv1alpha1.PatchTypeStrategicMerge, Patch: `{"spec": {"selector": {"kamaji.clastix.io/name": "tenant-00"}}}`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: tt.tcp}}
+			tcp.Spec.ControlPlane.Service.ServiceType = kamajiv1alpha1.ServiceTypeLoadBalancer
+			tcp.Spec.NetworkProfile.Port = 6443
+			tcp.Spec.Patches = []kamajiv1alpha1.Patch{tt.patch}
+
+			_, err := (TenantControlPlanePatches{Client: patchesTestClient(t, existing)}).OnCreate(tcp)(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
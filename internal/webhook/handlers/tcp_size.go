@@ -0,0 +1,63 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneSize struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneSize) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.check(ctx, tcp.Spec.ControlPlane.Deployment.Size)
+	}
+}
+
+func (t TenantControlPlaneSize) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSize) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		// The ControlPlaneSize could have been deleted, the updates not changing the size must be allowed.
+		if newTCP.Spec.ControlPlane.Deployment.Size == oldTCP.Spec.ControlPlane.Deployment.Size {
+			return nil, nil
+		}
+
+		return nil, t.check(ctx, newTCP.Spec.ControlPlane.Deployment.Size)
+	}
+}
+
+func (t TenantControlPlaneSize) check(ctx context.Context, sizeName string) error {
+	if len(sizeName) == 0 {
+		return nil
+	}
+
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: sizeName}, &kamajiv1alpha1.ControlPlaneSize{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("%s ControlPlaneSize does not exist", sizeName)
+		}
+
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane ControlPlaneSize check, %w", err)
+	}
+
+	return nil
+}
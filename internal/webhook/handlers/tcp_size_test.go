@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneSize(t *testing.T) {
+	handler := TenantControlPlaneSize{Client: networkPoolTestClient(t, &kamajiv1alpha1.ControlPlaneSize{ObjectMeta: metav1.ObjectMeta{Name: "small"}})}
+
+	sized := func(size string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-00"}}
+		tcp.Spec.ControlPlane.Deployment.Size = size
+
+		return tcp
+	}
+
+	tests := []struct {
+		name    string
+		tcp     *kamajiv1alpha1.TenantControlPlane
+		oldTCP  *kamajiv1alpha1.TenantControlPlane
+		wantErr bool
+	}{
+		{name: "no size", tcp: sized("")},
+		{name: "existing", tcp: sized("small")},
+		{name: "missing", tcp: sized("large"), wantErr: true},
+		{name: "changed to missing", tcp: sized("large"), oldTCP: sized("small"), wantErr: true},
+		{name: "unchanged missing", tcp: sized("large"), oldTCP: sized("large")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := handler.OnCreate(tt.tcp)
+			if tt.oldTCP != nil {
+				response = handler.OnUpdate(tt.tcp, tt.oldTCP)
+			}
+
+			_, err := response(context.Background(), admission.Request{})
+
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatal("expected error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
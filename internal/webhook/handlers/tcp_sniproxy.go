@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneSNIProxy struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneSNIProxy) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneSNIProxy) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSNIProxy) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneSNIProxy) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	sniProxy := tcp.Spec.ControlPlane.SNIProxy
+	if sniProxy == nil {
+		return nil
+	}
+
+	if tcp.Spec.ControlPlane.Service.ServiceType == kamajiv1alpha1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("the SNI proxy cannot be used along with a %s Service, since it would be exposed twice", corev1.ServiceTypeLoadBalancer)
+	}
+
+	if tcp.Spec.ControlPlane.Ingress != nil {
+		return fmt.Errorf("the SNI proxy cannot be used along with the Ingress")
+	}
+
+	if errs := validation.IsDNS1123Subdomain(sniProxy.Hostname); len(errs) > 0 {
+		return fmt.Errorf("the SNI proxy hostname %s is not valid: %s", sniProxy.Hostname, strings.Join(errs, ", "))
+	}
+
+	if net.ParseIP(tcp.Spec.NetworkProfile.Address) == nil {
+		return fmt.Errorf("the SNI proxy requires the network profile address to be set to the SNI proxy IP, got %q", tcp.Spec.NetworkProfile.Address)
+	}
+
+	if sniProxy.Port == 0 {
+		return fmt.Errorf("the SNI proxy port is required")
+	}
+
+	return t.checkCollisions(ctx, tcp)
+}
+
+// checkCollisions ensures the hostnames requested by the Tenant Control Plane
+// are not already routed by the SNI proxy to other Tenant Control Planes.
+func (t TenantControlPlaneSNIProxy) checkCollisions(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	hostnames := sniProxyHostnames(tcp)
+
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := t.Client.List(ctx, tcpList); err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane SNI proxy check, %w", err)
+	}
+
+	for i := range tcpList.Items {
+		item := tcpList.Items[i]
+
+		if item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName() {
+			continue
+		}
+
+		for hostname := range sniProxyHostnames(&item) {
+			if _, ok := hostnames[hostname]; ok {
+				return fmt.Errorf("the SNI proxy hostname %s is already used by the Tenant Control Plane %s/%s", hostname, item.GetNamespace(), item.GetName())
+			}
+		}
+	}
+
+	return nil
+}
+
+func sniProxyHostnames(tcp *kamajiv1alpha1.TenantControlPlane) map[string]struct{} {
+	hostnames := map[string]struct{}{}
+
+	if tcp.Spec.ControlPlane.SNIProxy == nil {
+		return hostnames
+	}
+
+	hostnames[strings.ToLower(tcp.Spec.ControlPlane.SNIProxy.Hostname)] = struct{}{}
+
+	if tcp.Spec.Addons.Konnectivity != nil {
+		hostnames[strings.ToLower(tcp.Spec.ControlPlane.SNIProxy.KonnectivityHostname())] = struct{}{}
+	}
+
+	return hostnames
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneSNIProxyDefaults is defaulting the advertised address, and the port,
+// of the Tenant Control Planes exposed using the shared SNI proxy.
+type TenantControlPlaneSNIProxyDefaults struct {
+	Address string
+	Port    int32
+}
+
+func (t TenantControlPlaneSNIProxyDefaults) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.defaults(tcp)
+	}
+}
+
+func (t TenantControlPlaneSNIProxyDefaults) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSNIProxyDefaults) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return t.defaults(tcp)
+	}
+}
+
+func (t TenantControlPlaneSNIProxyDefaults) defaults(tcp *kamajiv1alpha1.TenantControlPlane) ([]jsonpatch.JsonPatchOperation, error) {
+	sniProxy := tcp.Spec.ControlPlane.SNIProxy
+	if sniProxy == nil {
+		return nil, nil
+	}
+
+	if len(tcp.Spec.NetworkProfile.Address) > 0 && sniProxy.Port != 0 {
+		return nil, nil
+	}
+
+	operations, err := utils.JSONPatch(tcp, func() {
+		if len(tcp.Spec.NetworkProfile.Address) == 0 {
+			tcp.Spec.NetworkProfile.Address = t.Address
+		}
+
+		if sniProxy.Port == 0 {
+			sniProxy.Port = t.Port
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane SNI proxy defaulting")
+	}
+
+	return operations, nil
+}
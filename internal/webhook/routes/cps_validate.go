@@ -0,0 +1,22 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package routes
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/validate-kamaji-clastix-io-v1alpha1-controlplanesize,mutating=false,failurePolicy=fail,sideEffects=None,groups=kamaji.clastix.io,resources=controlplanesizes,verbs=delete,versions=v1alpha1,name=vcontrolplanesize.kb.io,admissionReviewVersions=v1
+
+type ControlPlaneSizeValidate struct{}
+
+func (c ControlPlaneSizeValidate) GetPath() string {
+	return "/validate-kamaji-clastix-io-v1alpha1-controlplanesize"
+}
+
+func (c ControlPlaneSizeValidate) GetObject() runtime.Object {
+	return &kamajiv1alpha1.ControlPlaneSize{}
+}